@@ -0,0 +1,140 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AppImportPlan is the result of preparing an app import. It is committed with CommitAppImport.
+type AppImportPlan struct {
+	ImportID  string
+	Errors    []ImportError
+	Conflicts []ImportConflict
+	Groups    *OwnershipGroups
+}
+
+// matchGroups checks that plan can be committed with opts and returns the ownership group
+// matching sent with the commit.
+func (p *AppImportPlan) matchGroups(opts *ImportAppOptions) (map[string]string, error) {
+	if opts == nil {
+		opts = &ImportAppOptions{}
+	}
+	if err := p.Err(); err != nil && !opts.IgnoreErrors {
+		return nil, err
+	}
+	return MatchOwnershipGroups(p.Groups, opts.GroupMapping)
+}
+
+// ImportError is an error reported by Pulse while preparing an app import.
+type ImportError struct {
+	Code    string
+	Message string
+	// Raw is the error as returned by Pulse.
+	Raw json.RawMessage
+}
+
+// ImportConflict is an entity of the imported app clashing with an entity of the destination.
+type ImportConflict struct {
+	Type   string
+	ID     string
+	Desc   string
+	Reason string
+}
+
+// Err returns an error summarizing the errors reported by Pulse, or nil if there are none.
+func (p *AppImportPlan) Err() error {
+	if len(p.Errors) == 0 {
+		return nil
+	}
+	messages := make([]string, len(p.Errors))
+	for i, e := range p.Errors {
+		messages[i] = e.Message
+	}
+	return fmt.Errorf("pulse: import %s has %d errors: %s", p.ImportID, len(p.Errors), strings.Join(messages, "; "))
+}
+
+// PrepareAppImport uploads the app export filename and returns what Pulse reports about it,
+// without importing anything.
+func (c *Client) PrepareAppImport(ctx context.Context, filename string) (*AppImportPlan, error) {
+	return c.prepareAppImport(ctx, filename)
+}
+
+// CommitAppImport imports the app prepared in plan and starts the application. It fails without
+// importing when plan has errors, unless opts.IgnoreErrors is set.
+func (c *Client) CommitAppImport(ctx context.Context, plan *AppImportPlan, opts *ImportAppOptions) error {
+	args := map[string]string{"importID": plan.ImportID}
+	return c.mutate(ctx, "ImportApp", args, func() error {
+		return c.commitAppImport(ctx, plan, opts)
+	})
+}
+
+func (c *Client) prepareAppImport(ctx context.Context, filename string) (*AppImportPlan, error) {
+	resp, err := c.prepareImport(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &AppImportPlan{
+		ImportID:  resp.ImportID,
+		Errors:    make([]ImportError, len(resp.Errors)),
+		Conflicts: make([]ImportConflict, len(resp.Conflicts)),
+		Groups:    newOwnershipGroups(resp.OwnershipGroups),
+	}
+	for i, raw := range resp.Errors {
+		plan.Errors[i] = newImportError(raw)
+	}
+	for i, conflict := range resp.Conflicts {
+		plan.Conflicts[i] = ImportConflict{
+			Type:   conflict.Type,
+			ID:     conflict.ID,
+			Desc:   conflict.Desc,
+			Reason: conflict.Reason,
+		}
+	}
+	return plan, nil
+}
+
+func (c *Client) commitAppImport(ctx context.Context, plan *AppImportPlan, opts *ImportAppOptions) error {
+	matching, err := plan.matchGroups(opts)
+	if err != nil {
+		// The rejected import would stay pending otherwise; err is what the caller needs.
+		c.cancelImport(ctx, plan.ImportID)
+		return err
+	}
+
+	if err := c.commitImport(ctx, plan.ImportID, matching); err != nil {
+		return err
+	}
+	return c.start(ctx)
+}
+
+// newImportError decodes an error reported by Pulse, which is either a plain string or an
+// object with a message.
+func newImportError(raw json.RawMessage) ImportError {
+	e := ImportError{Raw: raw}
+
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		e.Message = message
+		return e
+	}
+
+	var object struct {
+		Code    string `json:"code"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil {
+		e.Code = object.Code
+		e.Message = object.Message
+		if e.Message == "" {
+			e.Message = object.Error
+		}
+	}
+	if e.Message == "" {
+		e.Message = string(raw)
+	}
+	return e
+}
@@ -0,0 +1,65 @@
+package pulse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCommitAppImportCancelsRejectedPlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    *AppImportPlan
+		opts    *ImportAppOptions
+		wantErr string
+	}{
+		{
+			name:    "prepare errors",
+			plan:    &AppImportPlan{ImportID: "i1", Errors: []ImportError{{Message: "bad schema"}}, Groups: &OwnershipGroups{}},
+			wantErr: "bad schema",
+		},
+		{
+			name: "unmapped group",
+			plan: &AppImportPlan{ImportID: "i1", Groups: &OwnershipGroups{
+				Source: OwnershipGroup{ID: "root", Children: []OwnershipGroup{{ID: "g1", Name: "ops"}}},
+				Target: OwnershipGroup{ID: "root2"},
+			}},
+			wantErr: "unmapped ownership groups",
+		},
+		{
+			name: "unknown target group",
+			plan: &AppImportPlan{ImportID: "i1", Groups: &OwnershipGroups{
+				Source: OwnershipGroup{ID: "root", Children: []OwnershipGroup{{ID: "g1", Name: "ops"}}},
+				Target: OwnershipGroup{ID: "root2"},
+			}},
+			opts:    &ImportAppOptions{GroupMapping: map[string]string{"ops": "missing"}},
+			wantErr: "group missing not found",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/sessions") {
+					calls = append(calls, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(&Options{BaseURL: server.URL, AppName: "app", DisableAudit: true, DisableSnapshots: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = client.CommitAppImport(context.Background(), test.plan, test.opts)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want %q", err, test.wantErr)
+			}
+			if want := []string{"/pulseviews/api/apps/importCancel/i1"}; strings.Join(calls, " ") != want[0] {
+				t.Errorf("got calls %q, want %q", calls, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// AppClient is a client scoped to a single Pulse application. It shares the authenticated
+// session, HTTP transport and audit sink of the Client it was created from. Like every client of
+// the process, it serializes its mutating calls with the other clients of the same application.
+type AppClient struct {
+	*Client
+}
+
+// App returns a handle on the application named name using the session of c.
+func (c *Client) App(name string) *AppClient {
+	scoped := *c
+	scoped.appName = name
+	return &AppClient{Client: &scoped}
+}
+
+// AppName returns the name of the application the client operates on.
+func (c *Client) AppName() string {
+	return c.appName
+}
+
+// AppInfo describes a Pulse application.
+type AppInfo struct {
+	Name      string
+	Desc      string
+	Status    string
+	Owners    []string
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// ListApps returns the applications visible to the authenticated user. Owners holds the
+// identifiers of the ownership groups of each application.
+func (c *Client) ListApps(ctx context.Context) ([]AppInfo, error) {
+	apps := make([]AppInfo, 0)
+	offset := 0
+
+	for {
+		url := fmt.Sprintf("%s/pulseviews/api/apps/paged?limit=50&sort_by=name&order=ASC&offset=%d&_=%d",
+			c.baseURL, offset, time.Now().UnixNano()/int64(time.Millisecond))
+		var page internal.Apps
+		if err := c.getJSON(ctx, url, &page); err != nil {
+			return nil, err
+		}
+
+		for _, app := range page.Items {
+			owners := make([]string, 0, len(app.Ownership.Groups))
+			for group := range app.Ownership.Groups {
+				owners = append(owners, group)
+			}
+			sort.Strings(owners)
+
+			apps = append(apps, AppInfo{
+				Name:      app.Name,
+				Desc:      app.Desc,
+				Status:    app.Status,
+				Owners:    owners,
+				CreatedBy: app.CreatedBy,
+				CreatedAt: fromMillis(app.CreatedAt),
+				UpdatedBy: app.UpdatedBy,
+				UpdatedAt: fromMillis(app.UpdatedAt),
+			})
+		}
+		offset += len(page.Items)
+		if page.LastPage || len(page.Items) == 0 || offset >= page.CollectionSize {
+			break
+		}
+	}
+
+	return apps, nil
+}
@@ -0,0 +1,272 @@
+// Package archive reads Pulse app exports and partial exports locally, without contacting
+// the server.
+//
+// An export is a zip file holding one JSON document per entity, grouped in directories named
+// after the entity type (managedlists, models, plans, rulesprojects, snapshots, rte_workflows,
+// schemas). App exports also hold an app.json descriptor. Entities are recognized by the name
+// of their directory, or else by their @type property.
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Kind is the type of an entity of an export.
+type Kind string
+
+// Entity kinds.
+const (
+	KindApp          Kind = "app"
+	KindList         Kind = "list"
+	KindListItems    Kind = "listItems"
+	KindModel        Kind = "model"
+	KindPlan         Kind = "plan"
+	KindRulesProject Kind = "rulesProject"
+	KindSnapshot     Kind = "snapshot"
+	KindWorkflow     Kind = "workflow"
+	KindSchema       Kind = "schema"
+	KindOther        Kind = "other"
+)
+
+// directoryKinds maps directory names, lower cased, to the kind of the entities they hold.
+var directoryKinds = map[string]Kind{
+	"managedlistitems": KindListItems,
+	"listitems":        KindListItems,
+	"managedlists":     KindList,
+	"lists":            KindList,
+	"models":           KindModel,
+	"plans":            KindPlan,
+	"rulesprojects":    KindRulesProject,
+	"rules_projects":   KindRulesProject,
+	"snapshots":        KindSnapshot,
+	"rte_workflows":    KindWorkflow,
+	"workflows":        KindWorkflow,
+	"schemas":          KindSchema,
+}
+
+// typeKinds maps fragments of @type properties, lower cased, to entity kinds. Longer
+// fragments are checked first.
+var typeKinds = []struct {
+	fragment string
+	kind     Kind
+}{
+	{"rulesproject", KindRulesProject},
+	{"managedlist", KindList},
+	{"snapshot", KindSnapshot},
+	{"workflow", KindWorkflow},
+	{"schema", KindSchema},
+	{"model", KindModel},
+	{"plan", KindPlan},
+}
+
+// Entity is a JSON document of an export. Files holding an array yield one entity per element.
+type Entity struct {
+	Path   string
+	Kind   Kind
+	ID     string
+	Desc   string
+	Type   string
+	Object map[string]interface{}
+}
+
+// Archive is the content of an export.
+type Archive struct {
+	// Files holds the content of every file of the export by name.
+	Files map[string][]byte
+	// Entities holds the entities of the JSON files, sorted by path.
+	Entities []*Entity
+}
+
+// Open reads the export filename.
+func Open(filename string) (*Archive, error) {
+	reader, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return read(&reader.Reader)
+}
+
+// NewReader reads an export from r, which has the given size.
+func NewReader(r io.ReaderAt, size int64) (*Archive, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return read(reader)
+}
+
+func read(reader *zip.Reader) (*Archive, error) {
+	a := &Archive{Files: make(map[string][]byte)}
+
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		a.Files[file.Name] = data
+
+		if strings.EqualFold(path.Ext(file.Name), ".json") {
+			entities, err := decodeEntities(file.Name, data)
+			if err != nil {
+				return nil, err
+			}
+			a.Entities = append(a.Entities, entities...)
+		} else if kind := pathKind(file.Name); kind == KindListItems {
+			a.Entities = append(a.Entities, &Entity{
+				Path: file.Name,
+				Kind: kind,
+				ID:   strings.TrimSuffix(path.Base(file.Name), path.Ext(file.Name)),
+			})
+		}
+	}
+
+	sort.SliceStable(a.Entities, func(i, j int) bool {
+		return a.Entities[i].Path < a.Entities[j].Path
+	})
+	return a, nil
+}
+
+func decodeEntities(name string, data []byte) ([]*Entity, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, &FileError{Path: name, Err: err}
+	}
+
+	var objects []map[string]interface{}
+	switch v := document.(type) {
+	case map[string]interface{}:
+		objects = append(objects, v)
+	case []interface{}:
+		for _, item := range v {
+			if object, ok := item.(map[string]interface{}); ok {
+				objects = append(objects, object)
+			}
+		}
+	}
+
+	entities := make([]*Entity, len(objects))
+	for i, object := range objects {
+		entities[i] = newEntity(name, object)
+	}
+	return entities, nil
+}
+
+func newEntity(name string, object map[string]interface{}) *Entity {
+	e := &Entity{
+		Path:   name,
+		ID:     StringProperty(object, "id"),
+		Desc:   StringProperty(object, "desc"),
+		Type:   StringProperty(object, "@type"),
+		Object: object,
+	}
+	if e.Desc == "" {
+		e.Desc = StringProperty(object, "name")
+	}
+
+	e.Kind = pathKind(name)
+	if e.Kind == KindOther {
+		e.Kind = typeKind(e.Type)
+	}
+	if e.Kind == KindOther && isAppDescriptor(name) {
+		e.Kind = KindApp
+	}
+	return e
+}
+
+// pathKind returns the kind of the deepest directory of name that holds a known kind.
+func pathKind(name string) Kind {
+	dirs := strings.Split(path.Dir(name), "/")
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if kind, ok := directoryKinds[strings.ToLower(dirs[i])]; ok {
+			return kind
+		}
+	}
+	return KindOther
+}
+
+func typeKind(t string) Kind {
+	t = strings.ToLower(t)
+	for _, k := range typeKinds {
+		if strings.Contains(t, k.fragment) {
+			return k.kind
+		}
+	}
+	return KindOther
+}
+
+func isAppDescriptor(name string) bool {
+	base := strings.ToLower(path.Base(name))
+	return base == "app.json" || base == "application.json"
+}
+
+// Kind returns the entities of the given kind.
+func (a *Archive) Kind(kind Kind) []*Entity {
+	var entities []*Entity
+	for _, e := range a.Entities {
+		if e.Kind == kind {
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+// Entity returns the entity of the given kind identified by ref, an ID or a name, or nil.
+func (a *Archive) Entity(kind Kind, ref string) *Entity {
+	for _, e := range a.Entities {
+		if e.Kind == kind && e.ID == ref {
+			return e
+		}
+	}
+	for _, e := range a.Entities {
+		if e.Kind == kind && e.Desc == ref {
+			return e
+		}
+	}
+	return nil
+}
+
+// FileError reports a file of an export that could not be decoded.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return "archive: " + e.Path + ": " + e.Err.Error()
+}
+
+// StringProperty returns the string property key of object, or an empty string.
+func StringProperty(object map[string]interface{}, key string) string {
+	s, _ := object[key].(string)
+	return s
+}
+
+// ObjectsProperty returns the objects of the array property key of object.
+func ObjectsProperty(object map[string]interface{}, key string) []map[string]interface{} {
+	items, _ := object[key].([]interface{})
+	objects := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if o, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
@@ -0,0 +1,96 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+// newArchive returns the archive of a zip file holding files.
+func newArchive(t *testing.T, files map[string]string) *Archive {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestManifestListItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  string
+		items string
+		want  int
+	}{
+		{"inline items", `{"id": "l1", "items": [{"value": "a"}, {"value": "b"}]}`, "", 2},
+		{"csv with header", `{"id": "l1"}`, "value\na\nb\nc\n", 3},
+		{"csv without trailing newline", `{"id": "l1"}`, "value\na", 1},
+		{"csv header only", `{"id": "l1"}`, "value\n", 0},
+		{"empty csv", `{"id": "l1"}`, "", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := map[string]string{"managedLists/l1.json": test.list}
+			if test.items != "" {
+				files["managedListItems/l1.csv"] = test.items
+			}
+			m := newArchive(t, files).Manifest()
+			if len(m.Lists) != 1 {
+				t.Fatalf("got %d lists, want 1", len(m.Lists))
+			}
+			if got := m.Lists[0].Items; got != test.want {
+				t.Errorf("got %d items, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestManifestEntities(t *testing.T) {
+	a := newArchive(t, map[string]string{
+		"app.json":                  `{"id": "fraud", "desc": "Fraud"}`,
+		"models/m1.json":            `{"id": "m1", "desc": "Model", "@type": "pmml"}`,
+		"plans/p1.json":             `{"id": "p1", "executions": [{"id": "e1"}, {"id": "e2"}]}`,
+		"rulesProjects/r1.json":     `{"id": "r1", "rules": [{"id": "a"}], "snapshots": [{"id": "s1"}]}`,
+		"snapshots/s2.json":         `{"id": "s2", "rulesProjectId": "r1"}`,
+		"schemas/input.json":        `{"id": "input", "fields": [{"name": "amount", "type": "double"}]}`,
+		"rte_workflows/wf1.json":    `{"id": "wf1", "outcomeConfig": {"outcomes": [{"id": "score"}]}}`,
+		"unrelated/notes.txt":       "ignored",
+		"managedLists/ignored.json": `[]`,
+	})
+	m := a.Manifest()
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"partial", m.Partial, false},
+		{"app", m.App, "Fraud"},
+		{"models", len(m.Models), 1},
+		{"plan executions", len(m.Plans[0].Executions), 2},
+		{"project rules", m.RulesProjects[0].Rules, 1},
+		{"project snapshots", len(m.RulesProjects[0].Snapshots), 2},
+		{"schema fields", len(m.Schemas[0].Fields), 1},
+		{"workflow outcomes", len(m.Workflows[0].Outcomes), 1},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package archive
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// VolatileFields are the properties ignored when comparing exports.
+var VolatileFields = map[string]bool{
+	"createdAt": true,
+	"createdBy": true,
+	"updatedAt": true,
+	"updatedBy": true,
+}
+
+// conditionFields are the rule properties reported as condition changes.
+var conditionFields = map[string]bool{
+	"condition":  true,
+	"conditions": true,
+	"expression": true,
+}
+
+// Op is the type of a change between two exports.
+type Op string
+
+// Change types.
+const (
+	Added   Op = "added"
+	Removed Op = "removed"
+	Changed Op = "changed"
+)
+
+// Change is a difference between two exports.
+type Change struct {
+	Op Op
+	// Kind is the kind of the changed item: an entity kind, or snapshot, rule, condition,
+	// element, connection or outcome.
+	Kind string
+	// Path names the item and its parents, separated by slashes.
+	Path string
+	ID   string
+	// Fields lists the properties that differ, for changed items.
+	Fields []string
+}
+
+func (c Change) String() string {
+	symbol := map[Op]string{Added: "+", Removed: "-", Changed: "~"}[c.Op]
+	s := fmt.Sprintf("%s %s %s", symbol, c.Kind, c.Path)
+	if c.ID != "" && !strings.HasSuffix(c.Path, c.ID) {
+		s += " (" + c.ID + ")"
+	}
+	if len(c.Fields) > 0 {
+		s += ": " + strings.Join(c.Fields, ", ")
+	}
+	return s
+}
+
+// Diff holds the changes needed to turn an export into another one.
+type Diff struct {
+	Changes []Change
+}
+
+// Empty reports whether the exports are equivalent.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// WriteTo writes the changes to w, one per line.
+func (d *Diff) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	for _, c := range d.Changes {
+		written, err := fmt.Fprintln(w, c.String())
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (d *Diff) add(op Op, kind, path, id string, fields ...string) {
+	d.Changes = append(d.Changes, Change{Op: op, Kind: kind, Path: path, ID: id, Fields: fields})
+}
+
+// DiffExports compares the exports a and b, ignoring VolatileFields. Rules projects and their
+// embedded snapshots are compared rule by rule, and workflows element by element, connection by
+// connection and outcome by outcome.
+func DiffExports(a, b *Archive) *Diff {
+	d := &Diff{}
+	kinds := []Kind{KindApp, KindList, KindModel, KindPlan, KindSchema, KindRulesProject, KindSnapshot, KindWorkflow}
+	for _, kind := range kinds {
+		d.diffEntities(kind, a.Kind(kind), b.Kind(kind))
+	}
+	return d
+}
+
+func (d *Diff) diffEntities(kind Kind, a, b []*Entity) {
+	before := indexEntities(a)
+	after := indexEntities(b)
+
+	for _, key := range unionKeys(before, after) {
+		ea, eb := before[key], after[key]
+		switch {
+		case ea == nil:
+			d.add(Added, string(kind), entityName(eb), eb.ID)
+		case eb == nil:
+			d.add(Removed, string(kind), entityName(ea), ea.ID)
+		default:
+			d.diffEntity(kind, ea, eb)
+		}
+	}
+}
+
+func (d *Diff) diffEntity(kind Kind, a, b *Entity) {
+	name := entityName(b)
+	var nested []string
+
+	switch kind {
+	case KindRulesProject, KindSnapshot:
+		nested = []string{"rules", "snapshots"}
+		d.diffRules(name, ObjectsProperty(a.Object, "rules"), ObjectsProperty(b.Object, "rules"))
+		d.diffItems("snapshot", name, ObjectsProperty(a.Object, "snapshots"), ObjectsProperty(b.Object, "snapshots"), d.diffSnapshot)
+	case KindWorkflow:
+		nested = []string{"config", "outcomeConfig"}
+		configA, _ := a.Object["config"].(map[string]interface{})
+		configB, _ := b.Object["config"].(map[string]interface{})
+		d.diffItems("element", name, ObjectsProperty(configA, "elements"), ObjectsProperty(configB, "elements"), nil)
+		d.diffItems("connection", name, ObjectsProperty(configA, "connections"), ObjectsProperty(configB, "connections"), nil)
+		if fields := changedFields(configA, configB, "elements", "connections"); len(fields) > 0 {
+			d.add(Changed, string(kind), name+"/config", b.ID, fields...)
+		}
+
+		outcomesA, _ := a.Object["outcomeConfig"].(map[string]interface{})
+		outcomesB, _ := b.Object["outcomeConfig"].(map[string]interface{})
+		d.diffItems("outcome", name, ObjectsProperty(outcomesA, "outcomes"), ObjectsProperty(outcomesB, "outcomes"), nil)
+		if fields := changedFields(outcomesA, outcomesB, "outcomes"); len(fields) > 0 {
+			d.add(Changed, string(kind), name+"/outcomeConfig", b.ID, fields...)
+		}
+	}
+
+	if fields := changedFields(a.Object, b.Object, nested...); len(fields) > 0 {
+		d.add(Changed, string(kind), name, b.ID, fields...)
+	}
+}
+
+func (d *Diff) diffRules(parent string, a, b []map[string]interface{}) {
+	d.diffItems("rule", parent, a, b, func(path, id string, ra, rb map[string]interface{}) {
+		var conditions []string
+		for field := range conditionFields {
+			if !equal(ra[field], rb[field]) {
+				conditions = append(conditions, field)
+			}
+		}
+		if len(conditions) > 0 {
+			sort.Strings(conditions)
+			d.add(Changed, "condition", path, id, conditions...)
+		}
+
+		ignored := make([]string, 0, len(conditionFields))
+		for field := range conditionFields {
+			ignored = append(ignored, field)
+		}
+		if fields := changedFields(ra, rb, ignored...); len(fields) > 0 {
+			d.add(Changed, "rule", path, id, fields...)
+		}
+	})
+}
+
+// diffSnapshot compares two snapshots embedded in a rules project rule by rule.
+func (d *Diff) diffSnapshot(path, id string, a, b map[string]interface{}) {
+	d.diffRules(path, ObjectsProperty(a, "rules"), ObjectsProperty(b, "rules"))
+	if fields := changedFields(a, b, "rules"); len(fields) > 0 {
+		d.add(Changed, "snapshot", path, id, fields...)
+	}
+}
+
+// diffItems compares two arrays of objects matched by id. changed, when not nil, compares
+// the items present in both arrays instead of the default property comparison.
+func (d *Diff) diffItems(kind, parent string, a, b []map[string]interface{}, changed func(path, id string, a, b map[string]interface{})) {
+	before := indexObjects(a)
+	after := indexObjects(b)
+
+	for _, key := range unionKeys(before, after) {
+		oa, ob := before[key], after[key]
+		switch {
+		case oa == nil:
+			d.add(Added, kind, parent+"/"+objectName(ob, key), StringProperty(ob, "id"))
+		case ob == nil:
+			d.add(Removed, kind, parent+"/"+objectName(oa, key), StringProperty(oa, "id"))
+		case changed != nil:
+			changed(parent+"/"+objectName(ob, key), StringProperty(ob, "id"), oa, ob)
+		default:
+			if fields := changedFields(oa, ob); len(fields) > 0 {
+				d.add(Changed, kind, parent+"/"+objectName(ob, key), StringProperty(ob, "id"), fields...)
+			}
+		}
+	}
+}
+
+// changedFields returns the sorted properties that differ between a and b, ignoring
+// VolatileFields and the given properties.
+func changedFields(a, b map[string]interface{}, ignored ...string) []string {
+	skip := make(map[string]bool, len(ignored))
+	for _, field := range ignored {
+		skip[field] = true
+	}
+
+	var fields []string
+	for _, key := range unionKeys(a, b) {
+		if VolatileFields[key] || skip[key] {
+			continue
+		}
+		if !equal(a[key], b[key]) {
+			fields = append(fields, key)
+		}
+	}
+	return fields
+}
+
+// equal compares two JSON values, ignoring VolatileFields in nested objects.
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		n := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if !VolatileFields[key] {
+				n[key] = normalize(child)
+			}
+		}
+		return n
+	case []interface{}:
+		n := make([]interface{}, len(v))
+		for i, child := range v {
+			n[i] = normalize(child)
+		}
+		return n
+	}
+	return value
+}
+
+func indexEntities(entities []*Entity) map[string]*Entity {
+	index := make(map[string]*Entity, len(entities))
+	for _, e := range entities {
+		key := e.ID
+		if key == "" {
+			key = e.Desc
+		}
+		if key == "" {
+			key = e.Path
+		}
+		index[key] = e
+	}
+	return index
+}
+
+func indexObjects(objects []map[string]interface{}) map[string]map[string]interface{} {
+	index := make(map[string]map[string]interface{}, len(objects))
+	for i, object := range objects {
+		key := StringProperty(object, "id")
+		if key == "" {
+			key = StringProperty(object, "desc")
+		}
+		if key == "" {
+			key = fmt.Sprintf("#%d", i)
+		}
+		index[key] = object
+	}
+	return index
+}
+
+// unionKeys returns the sorted keys of maps, which are maps with string keys.
+func unionKeys(maps ...interface{}) []string {
+	seen := make(map[string]bool)
+	for _, m := range maps {
+		for _, key := range reflect.ValueOf(m).MapKeys() {
+			seen[key.String()] = true
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func entityName(e *Entity) string {
+	if e.Desc != "" {
+		return e.Desc
+	}
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Path
+}
+
+func objectName(object map[string]interface{}, key string) string {
+	if desc := StringProperty(object, "desc"); desc != "" {
+		return desc
+	}
+	if name := StringProperty(object, "name"); name != "" {
+		return name
+	}
+	return key
+}
@@ -0,0 +1,77 @@
+package archive
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffExports(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]string
+		after  map[string]string
+		want   []string
+	}{
+		{
+			name:   "identical",
+			before: map[string]string{"models/m1.json": `{"id": "m1", "desc": "Model"}`},
+			after:  map[string]string{"models/m1.json": `{"id": "m1", "desc": "Model"}`},
+		},
+		{
+			name:   "volatile fields",
+			before: map[string]string{"models/m1.json": `{"id": "m1", "updatedAt": 1, "updatedBy": "a"}`},
+			after:  map[string]string{"models/m1.json": `{"id": "m1", "updatedAt": 2, "updatedBy": "b"}`},
+		},
+		{
+			name:   "added and removed entities",
+			before: map[string]string{"models/m1.json": `{"id": "m1"}`},
+			after:  map[string]string{"models/m2.json": `{"id": "m2"}`},
+			want:   []string{"- model m1", "+ model m2"},
+		},
+		{
+			name:   "rule condition",
+			before: map[string]string{"rulesProjects/p.json": `{"id": "p", "rules": [{"id": "r1", "condition": "a > 1"}]}`},
+			after:  map[string]string{"rulesProjects/p.json": `{"id": "p", "rules": [{"id": "r1", "condition": "a > 2"}]}`},
+			want:   []string{"~ condition p/r1: condition"},
+		},
+		{
+			name:   "embedded snapshot rules",
+			before: map[string]string{"rulesProjects/p.json": `{"id": "p", "snapshots": [{"id": "s1", "rules": [{"id": "r1", "score": 1}]}]}`},
+			after:  map[string]string{"rulesProjects/p.json": `{"id": "p", "snapshots": [{"id": "s1", "rules": [{"id": "r1", "score": 2}, {"id": "r2"}]}]}`},
+			want:   []string{"~ rule p/s1/r1: score", "+ rule p/s1/r2"},
+		},
+		{
+			name:   "embedded snapshot properties",
+			before: map[string]string{"rulesProjects/p.json": `{"id": "p", "snapshots": [{"id": "s1", "desc": "v1"}, {"id": "s2"}]}`},
+			after:  map[string]string{"rulesProjects/p.json": `{"id": "p", "snapshots": [{"id": "s1", "desc": "v2"}, {"id": "s3"}]}`},
+			want:   []string{"~ snapshot p/v2 (s1): desc", "- snapshot p/s2", "+ snapshot p/s3"},
+		},
+		{
+			name:   "workflow outcome config",
+			before: map[string]string{"rte_workflows/w.json": `{"id": "w", "outcomeConfig": {"mode": "first", "outcomes": [{"id": "o1"}]}}`},
+			after:  map[string]string{"rte_workflows/w.json": `{"id": "w", "outcomeConfig": {"mode": "all", "outcomes": [{"id": "o1"}]}}`},
+			want:   []string{"~ workflow w/outcomeConfig (w): mode"},
+		},
+		{
+			name:   "workflow elements",
+			before: map[string]string{"rte_workflows/w.json": `{"id": "w", "config": {"elements": [{"id": "e1", "x": 1}]}}`},
+			after:  map[string]string{"rte_workflows/w.json": `{"id": "w", "config": {"elements": [{"id": "e1", "x": 2}]}}`},
+			want:   []string{"~ element w/e1: x"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := DiffExports(newArchive(t, test.before), newArchive(t, test.after))
+			var got []string
+			for _, c := range d.Changes {
+				got = append(got, c.String())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if d.Empty() != (len(test.want) == 0) {
+				t.Errorf("Empty() = %v", d.Empty())
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package archive
+
+import (
+	"path"
+	"strings"
+)
+
+// Manifest summarizes the content of an export.
+type Manifest struct {
+	// App is the name of the exported application, empty for partial exports.
+	App           string
+	Partial       bool
+	Lists         []List
+	Models        []Model
+	Plans         []Plan
+	RulesProjects []RulesProject
+	Workflows     []Workflow
+	Schemas       []Schema
+}
+
+// List is a managed list of an export.
+type List struct {
+	ID    string
+	Desc  string
+	Type  string
+	Path  string
+	Items int
+}
+
+// Model is a machine learning model of an export.
+type Model struct {
+	ID   string
+	Desc string
+	Type string
+	Path string
+}
+
+// Plan is an execution plan of an export.
+type Plan struct {
+	ID         string
+	Desc       string
+	Path       string
+	Executions []string
+}
+
+// RulesProject is a rules project of an export.
+type RulesProject struct {
+	ID        string
+	Desc      string
+	Path      string
+	Rules     int
+	Snapshots []Snapshot
+}
+
+// Snapshot is a snapshot of a rules project.
+type Snapshot struct {
+	ID    string
+	Desc  string
+	Path  string
+	Rules int
+}
+
+// Workflow is an RTE workflow of an export.
+type Workflow struct {
+	ID          string
+	Desc        string
+	Path        string
+	Elements    []string
+	Connections int
+	Outcomes    []string
+}
+
+// Schema is a data schema of an export.
+type Schema struct {
+	ID     string
+	Desc   string
+	Path   string
+	Fields []Field
+}
+
+// Field is a field of a schema.
+type Field struct {
+	Name string
+	Type string
+}
+
+// Manifest returns the summary of the export.
+func (a *Archive) Manifest() *Manifest {
+	m := &Manifest{Partial: true}
+	listItems := make(map[string]int)
+
+	for _, e := range a.Entities {
+		switch e.Kind {
+		case KindApp:
+			m.Partial = false
+			m.App = e.Desc
+			if m.App == "" {
+				m.App = e.ID
+			}
+		case KindListItems:
+			listItems[e.ID] = countLines(a.Files[e.Path])
+		case KindList:
+			m.Lists = append(m.Lists, List{
+				ID:    e.ID,
+				Desc:  e.Desc,
+				Type:  StringProperty(e.Object, "itemValuesType"),
+				Path:  e.Path,
+				Items: len(ObjectsProperty(e.Object, "items")),
+			})
+		case KindModel:
+			m.Models = append(m.Models, Model{ID: e.ID, Desc: e.Desc, Type: e.Type, Path: e.Path})
+		case KindPlan:
+			plan := Plan{ID: e.ID, Desc: e.Desc, Path: e.Path}
+			for _, exec := range ObjectsProperty(e.Object, "executions") {
+				plan.Executions = append(plan.Executions, StringProperty(exec, "id"))
+			}
+			m.Plans = append(m.Plans, plan)
+		case KindRulesProject:
+			project := RulesProject{ID: e.ID, Desc: e.Desc, Path: e.Path, Rules: len(ObjectsProperty(e.Object, "rules"))}
+			for _, snapshot := range ObjectsProperty(e.Object, "snapshots") {
+				project.Snapshots = append(project.Snapshots, newSnapshot(e.Path, snapshot))
+			}
+			m.RulesProjects = append(m.RulesProjects, project)
+		case KindWorkflow:
+			m.Workflows = append(m.Workflows, newWorkflow(e))
+		case KindSchema:
+			schema := Schema{ID: e.ID, Desc: e.Desc, Path: e.Path}
+			for _, field := range ObjectsProperty(e.Object, "fields") {
+				schema.Fields = append(schema.Fields, Field{Name: StringProperty(field, "name"), Type: StringProperty(field, "type")})
+			}
+			m.Schemas = append(m.Schemas, schema)
+		}
+	}
+
+	for i := range m.Lists {
+		if n, ok := listItems[m.Lists[i].ID]; ok && m.Lists[i].Items == 0 {
+			m.Lists[i].Items = n
+		}
+	}
+
+	// Snapshots stored in their own files are attached to their project.
+	for _, e := range a.Kind(KindSnapshot) {
+		projectID := SnapshotProject(e)
+		for i := range m.RulesProjects {
+			if m.RulesProjects[i].ID == projectID {
+				m.RulesProjects[i].Snapshots = append(m.RulesProjects[i].Snapshots, newSnapshot(e.Path, e.Object))
+				break
+			}
+		}
+	}
+
+	return m
+}
+
+// SnapshotProject returns the identifier of the rules project of a snapshot, taken from its
+// properties or from the directory it is stored in.
+func SnapshotProject(e *Entity) string {
+	for _, key := range []string{"rulesProjectId", "projectId", "rulesProject"} {
+		if id := StringProperty(e.Object, key); id != "" {
+			return id
+		}
+	}
+
+	dirs := strings.Split(path.Dir(e.Path), "/")
+	for i := len(dirs) - 1; i > 0; i-- {
+		if strings.EqualFold(dirs[i], "snapshots") {
+			return dirs[i-1]
+		}
+	}
+	return ""
+}
+
+func newSnapshot(path string, object map[string]interface{}) Snapshot {
+	return Snapshot{
+		ID:    StringProperty(object, "id"),
+		Desc:  StringProperty(object, "desc"),
+		Path:  path,
+		Rules: len(ObjectsProperty(object, "rules")),
+	}
+}
+
+func newWorkflow(e *Entity) Workflow {
+	w := Workflow{ID: e.ID, Desc: e.Desc, Path: e.Path}
+	config, _ := e.Object["config"].(map[string]interface{})
+	for _, element := range ObjectsProperty(config, "elements") {
+		w.Elements = append(w.Elements, StringProperty(element, "desc"))
+	}
+	w.Connections = len(ObjectsProperty(config, "connections"))
+
+	outcomeConfig, _ := e.Object["outcomeConfig"].(map[string]interface{})
+	for _, outcome := range ObjectsProperty(outcomeConfig, "outcomes") {
+		w.Outcomes = append(w.Outcomes, StringProperty(outcome, "id"))
+	}
+	return w
+}
+
+// countLines returns the number of items of a list CSV file, not counting its header line.
+func countLines(data []byte) int {
+	s := strings.TrimRight(string(data), "\n")
+	if s == "" {
+		return 0
+	}
+	return strings.Count(s, "\n")
+}
@@ -0,0 +1,276 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Transform describes the changes applied to an export before importing it in another
+// environment.
+type Transform struct {
+	// AppFrom is the name of the application in the export. It defaults to the name found
+	// in the app descriptor.
+	AppFrom string
+	// AppName renames the application in the app descriptor and in the app and appName
+	// properties of the other documents.
+	AppName string
+	// ListIDs maps managed list identifiers to the identifiers used in the destination.
+	ListIDs map[string]string
+	// GroupIDs maps ownership group identifiers to the identifiers used in the destination.
+	GroupIDs map[string]string
+	// DropListItems removes the items of managed lists.
+	DropListItems bool
+	// Placeholders replaces every ${NAME} occurrence, where NAME is a key of the map, by its value.
+	Placeholders map[string]string
+}
+
+// Transform returns a copy of the export with t applied. List and group identifiers are replaced
+// in file names and in JSON string values equal to them; ownership group identifiers are also
+// replaced in the keys of ownership groups objects. The application name is only replaced where
+// it names the application, so entities sharing it keep their names.
+func (a *Archive) Transform(t *Transform) (*Archive, error) {
+	ids := make(map[string]string, len(t.ListIDs)+len(t.GroupIDs))
+	for from, to := range t.ListIDs {
+		ids[from] = to
+	}
+	for from, to := range t.GroupIDs {
+		ids[from] = to
+	}
+
+	rw := &rewriter{ids: ids, groupIDs: t.GroupIDs, dropListItems: t.DropListItems}
+	if t.AppName != "" {
+		rw.appFrom = t.AppFrom
+		if rw.appFrom == "" {
+			rw.appFrom = a.Manifest().App
+		}
+		rw.appName = t.AppName
+	}
+	if len(t.Placeholders) > 0 {
+		pairs := make([]string, 0, 2*len(t.Placeholders))
+		for name, value := range t.Placeholders {
+			pairs = append(pairs, "${"+name+"}", value)
+		}
+		rw.placeholders = strings.NewReplacer(pairs...)
+	}
+
+	out := &Archive{Files: make(map[string][]byte, len(a.Files))}
+	for _, name := range a.names() {
+		data := a.Files[name]
+		newName := rw.rewritePath(name)
+		if t.DropListItems && pathKind(newName) == KindListItems {
+			continue
+		}
+
+		if strings.EqualFold(path.Ext(name), ".json") {
+			var err error
+			if data, err = rw.rewriteJSON(name, data); err != nil {
+				return nil, &FileError{Path: name, Err: err}
+			}
+			entities, err := decodeEntities(newName, data)
+			if err != nil {
+				return nil, err
+			}
+			out.Entities = append(out.Entities, entities...)
+		} else if rw.placeholders != nil {
+			data = []byte(rw.placeholders.Replace(string(data)))
+		}
+		out.Files[newName] = data
+	}
+
+	sort.SliceStable(out.Entities, func(i, j int) bool {
+		return out.Entities[i].Path < out.Entities[j].Path
+	})
+	return out, nil
+}
+
+// Rewrite applies t to the export src and writes the result to dst.
+func Rewrite(src, dst string, t *Transform) error {
+	a, err := Open(src)
+	if err != nil {
+		return err
+	}
+	transformed, err := a.Transform(t)
+	if err != nil {
+		return err
+	}
+	return transformed.WriteFile(dst)
+}
+
+// WriteFile writes the export to the zip file filename.
+func (a *Archive) WriteFile(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := a.WriteTo(file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// WriteTo writes the export to w as a zip file.
+func (a *Archive) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	writer := zip.NewWriter(counter)
+	for _, name := range a.names() {
+		f, err := writer.Create(name)
+		if err != nil {
+			return counter.n, err
+		}
+		if _, err := f.Write(a.Files[name]); err != nil {
+			return counter.n, err
+		}
+	}
+	err := writer.Close()
+	return counter.n, err
+}
+
+func (a *Archive) names() []string {
+	names := make([]string, 0, len(a.Files))
+	for name := range a.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type rewriter struct {
+	ids           map[string]string
+	groupIDs      map[string]string
+	appFrom       string
+	appName       string
+	dropListItems bool
+	placeholders  *strings.Replacer
+}
+
+// appProperties are the properties holding the name of the application outside of its
+// descriptor.
+var appProperties = map[string]bool{"app": true, "appName": true}
+
+// descriptorProperties are the properties of the app descriptor naming the application.
+var descriptorProperties = map[string]bool{"id": true, "name": true, "desc": true}
+
+func (rw *rewriter) rewritePath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		ext := path.Ext(segment)
+		segments[i] = rw.rewriteString(strings.TrimSuffix(segment, ext)) + ext
+	}
+	return strings.Join(segments, "/")
+}
+
+func (rw *rewriter) rewriteString(value string) string {
+	if rw.placeholders != nil {
+		value = rw.placeholders.Replace(value)
+	}
+	if id, ok := rw.ids[value]; ok {
+		return id
+	}
+	return value
+}
+
+// rewriteJSON rewrites the JSON document name. The top level objects of documents stored in a
+// managed lists directory are lists, and the one of the app descriptor names the application.
+func (rw *rewriter) rewriteJSON(name string, data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	if rw.dropListItems && pathKind(name) == KindList {
+		switch v := document.(type) {
+		case map[string]interface{}:
+			dropItems(v)
+		case []interface{}:
+			for _, item := range v {
+				if object, ok := item.(map[string]interface{}); ok {
+					dropItems(object)
+				}
+			}
+		}
+	}
+
+	if descriptor, ok := document.(map[string]interface{}); ok && isAppDescriptor(name) {
+		rw.renameApp(descriptor, descriptorProperties)
+	}
+	document = rw.rewriteValue(document)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func (rw *rewriter) rewriteValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if rw.dropListItems && typeKind(StringProperty(v, "@type")) == KindList {
+			dropItems(v)
+		}
+		if groups, ok := v["groups"].(map[string]interface{}); ok && len(rw.groupIDs) > 0 {
+			renamed := make(map[string]interface{}, len(groups))
+			for id, rights := range groups {
+				if to, ok := rw.groupIDs[id]; ok {
+					id = to
+				}
+				renamed[id] = rights
+			}
+			v["groups"] = renamed
+		}
+		rw.renameApp(v, appProperties)
+		for k, child := range v {
+			v[k] = rw.rewriteValue(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = rw.rewriteValue(child)
+		}
+		return v
+	case string:
+		return rw.rewriteString(v)
+	}
+	return value
+}
+
+// renameApp replaces the application name in the given properties of object.
+func (rw *rewriter) renameApp(object map[string]interface{}, properties map[string]bool) {
+	if rw.appName == "" || rw.appFrom == "" {
+		return
+	}
+	for key := range properties {
+		if value, ok := object[key].(string); ok && value == rw.appFrom {
+			object[key] = rw.appName
+		}
+	}
+}
+
+func dropItems(list map[string]interface{}) {
+	if _, ok := list["items"]; ok {
+		list["items"] = []interface{}{}
+	}
+}
@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	files := map[string]string{
+		"app.json":                 `{"id": "fraud", "desc": "fraud"}`,
+		"managedLists/l1.json":     `{"id": "l1", "@type": "managedList", "items": [{"value": "a"}]}`,
+		"managedListItems/l1.csv":  "value\na\n",
+		"models/m1.json":           `{"id": "m1", "app": "fraud", "groups": {"g1": ["READ"]}, "url": "${HOST}/score"}`,
+		"rte_workflows/wf1.json":   `{"id": "wf1", "listId": "l1", "note": "<keep>"}`,
+		"unrelated/readme.txt":     "see ${HOST}",
+		"rulesProjects/fraud.json": `{"id": "fraud", "rules": [{"id": "r1", "desc": "fraud"}]}`,
+		"schemas/s1.json":          `{"id": "s1", "appName": "fraud", "fields": [{"name": "fraud"}]}`,
+	}
+	tests := []struct {
+		name      string
+		transform Transform
+		file      string
+		want      string
+		missing   string
+	}{
+		{"identity", Transform{}, "models/m1.json", `{"app":"fraud","groups":{"g1":["READ"]},"id":"m1","url":"${HOST}/score"}`, ""},
+		{"app name", Transform{AppName: "fraud2"}, "models/m1.json", `{"app":"fraud2","groups":{"g1":["READ"]},"id":"m1","url":"${HOST}/score"}`, ""},
+		{"app descriptor", Transform{AppName: "fraud2"}, "app.json", `{"desc":"fraud2","id":"fraud2"}`, ""},
+		{"entity sharing the app name", Transform{AppName: "fraud2"}, "rulesProjects/fraud.json", `{"id":"fraud","rules":[{"desc":"fraud","id":"r1"}]}`, ""},
+		{"app name property", Transform{AppName: "fraud2"}, "schemas/s1.json", `{"appName":"fraud2","fields":[{"name":"fraud"}],"id":"s1"}`, ""},
+		{"list ids", Transform{ListIDs: map[string]string{"l1": "l9"}}, "rte_workflows/wf1.json", `{"id":"wf1","listId":"l9","note":"<keep>"}`, "managedLists/l1.json"},
+		{"list item files", Transform{ListIDs: map[string]string{"l1": "l9"}}, "managedListItems/l9.csv", "value\na\n", ""},
+		{"group ids", Transform{GroupIDs: map[string]string{"g1": "g2"}}, "models/m1.json", `{"app":"fraud","groups":{"g2":["READ"]},"id":"m1","url":"${HOST}/score"}`, ""},
+		{"drop list items", Transform{DropListItems: true}, "managedLists/l1.json", `{"@type":"managedList","id":"l1","items":[]}`, "managedListItems/l1.csv"},
+		{"placeholders", Transform{Placeholders: map[string]string{"HOST": "https://pulse"}}, "models/m1.json", `{"app":"fraud","groups":{"g1":["READ"]},"id":"m1","url":"https://pulse/score"}`, ""},
+		{"placeholders in other files", Transform{Placeholders: map[string]string{"HOST": "https://pulse"}}, "unrelated/readme.txt", "see https://pulse", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := newArchive(t, files).Transform(&test.transform)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(out.Files[test.file]); got != test.want {
+				t.Errorf("%s: got %s, want %s", test.file, got, test.want)
+			}
+			if _, ok := out.Files[test.missing]; test.missing != "" && ok {
+				t.Errorf("%s was not removed", test.missing)
+			}
+		})
+	}
+}
+
+func TestTransformRoundTrip(t *testing.T) {
+	a := newArchive(t, map[string]string{
+		"app.json":             `{"id": "fraud"}`,
+		"managedLists/l1.json": `{"id": "l1"}`,
+	})
+	out, err := a.Transform(&Transform{AppName: "fraud2", ListIDs: map[string]string{"l1": "l2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := out.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	read, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := read.Manifest()
+	if m.App != "fraud2" || len(m.Lists) != 1 || m.Lists[0].ID != "l2" {
+		t.Errorf("got app %q and lists %+v", m.App, m.Lists)
+	}
+}
+
+func TestTransformInvalidJSON(t *testing.T) {
+	a := newArchive(t, map[string]string{"models/m1.json": `{}`})
+	a.Files["models/m1.json"] = []byte("{")
+	if _, err := a.Transform(&Transform{}); err == nil {
+		t.Error("got no error for an invalid JSON file")
+	}
+}
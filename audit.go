@@ -0,0 +1,103 @@
+package pulse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord describes a mutating call made through the client.
+type AuditRecord struct {
+	Time      time.Time         `json:"time"`
+	App       string            `json:"app"`
+	Actor     string            `json:"actor"`
+	Operation string            `json:"operation"`
+	Args      map[string]string `json:"args,omitempty"`
+	Duration  time.Duration     `json:"duration"`
+	Outcome   string            `json:"outcome"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// Audit outcomes.
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditSink stores audit records. Unless configured otherwise, clients append them to a JSON
+// lines file, see Options.AuditFile.
+type AuditSink interface {
+	Record(record *AuditRecord) error
+}
+
+// AuditFunc adapts a function to the AuditSink interface.
+type AuditFunc func(record *AuditRecord) error
+
+// Record calls f(record).
+func (f AuditFunc) Record(record *AuditRecord) error {
+	return f(record)
+}
+
+// FileAuditSink appends audit records to a file, one JSON document per line.
+type FileAuditSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileAuditSink opens filename for appending, creating it if needed.
+func NewFileAuditSink(filename string) (*FileAuditSink, error) {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileAuditSink{file: file}, nil
+}
+
+// Record writes record as a single JSON line.
+func (s *FileAuditSink) Record(record *AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the underlying file.
+func (s *FileAuditSink) Close() error {
+	return s.file.Close()
+}
+
+// audited runs fn and reports its outcome to the audit sink. An audit failure is
+// returned only when fn itself succeeded.
+func (c *Client) audited(operation string, args map[string]string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	if c.audit == nil {
+		return err
+	}
+
+	record := &AuditRecord{
+		Time:      start.UTC(),
+		App:       c.appName,
+		Actor:     c.username,
+		Operation: operation,
+		Args:      args,
+		Duration:  time.Since(start),
+		Outcome:   AuditSuccess,
+	}
+	if err != nil {
+		record.Outcome = AuditFailure
+		record.Error = err.Error()
+	}
+
+	if auditErr := c.audit.Record(record); auditErr != nil && err == nil {
+		return fmt.Errorf("pulse: audit: %v", auditErr)
+	}
+	return err
+}
@@ -2,6 +2,7 @@ package pulse
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -23,6 +24,14 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    string
 	appName    string
+	username   string
+	audit      AuditSink
+	guard      GuardPolicy
+	guardWait  time.Duration
+	pollEvery  time.Duration
+	workflows  *workflowCache
+	snapshots  SnapshotStore
+	closers    []io.Closer
 }
 
 // New returns a client, error will be non-nil if the authentication failed.
@@ -40,6 +49,31 @@ func New(options *Options) (*Client, error) {
 		httpClient: httpClient,
 		baseURL:    strings.TrimRight(options.BaseURL, "/"),
 		appName:    options.AppName,
+		username:   options.Username,
+		audit:      options.AuditSink,
+		guard:      options.Guard,
+		guardWait:  options.GuardTimeout,
+		pollEvery:  options.PollInterval,
+		workflows:  &workflowCache{},
+		snapshots:  options.SnapshotStore,
+	}
+
+	if client.pollEvery <= 0 {
+		client.pollEvery = defaultPollInterval
+	}
+
+	if client.snapshots == nil && !options.DisableSnapshots {
+		dir := options.SnapshotDir
+		if dir == "" {
+			if dir, err = defaultPath("snapshots"); err != nil {
+				return nil, err
+			}
+		}
+		store, err := NewDirSnapshotStore(dir)
+		if err != nil {
+			return nil, err
+		}
+		client.snapshots = store
 	}
 
 	creds := newCredentials(options.Username, options.Password)
@@ -50,7 +84,7 @@ func New(options *Options) (*Client, error) {
 
 	loginUrl := client.baseURL + "/pulseviews/api/sessions"
 
-	loginResp, err := client.post(loginUrl, credsPayload)
+	loginResp, err := client.post(context.Background(), loginUrl, credsPayload)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +98,37 @@ func New(options *Options) (*Client, error) {
 		return nil, fmt.Errorf("pulse: %s\n", body)
 	}
 
+	if client.audit == nil && !options.DisableAudit {
+		filename := options.AuditFile
+		if filename == "" {
+			if filename, err = defaultPath("audit.jsonl"); err != nil {
+				return nil, err
+			}
+		}
+		sink, err := NewFileAuditSink(filename)
+		if err != nil {
+			return nil, err
+		}
+		client.audit = sink
+		client.closers = append(client.closers, sink)
+	}
+
 	return client, nil
 }
 
-func (c *Client) do(url string, method string, payload []byte) (*http.Response, error) {
+// Close releases the resources opened by New, such as the default audit file. The clients
+// returned by App share them and must not be used afterwards.
+func (c *Client) Close() error {
+	var err error
+	for _, closer := range c.closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (c *Client) do(ctx context.Context, url string, method string, payload []byte) (*http.Response, error) {
 	var body io.Reader
 	if method == http.MethodGet || method == http.MethodDelete {
 		body = nil
@@ -75,7 +136,7 @@ func (c *Client) do(url string, method string, payload []byte) (*http.Response,
 		body = bytes.NewBuffer(payload)
 	}
 
-	request, err := http.NewRequest(method, url, body)
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -84,20 +145,37 @@ func (c *Client) do(url string, method string, payload []byte) (*http.Response,
 	return c.httpClient.Do(request)
 }
 
-func (c *Client) post(url string, payload []byte) (*http.Response, error) {
-	return c.do(url, http.MethodPost, payload)
+func (c *Client) post(ctx context.Context, url string, payload []byte) (*http.Response, error) {
+	return c.do(ctx, url, http.MethodPost, payload)
+}
+
+func (c *Client) put(ctx context.Context, url string, payload []byte) (*http.Response, error) {
+	return c.do(ctx, url, http.MethodPut, payload)
 }
 
-func (c *Client) put(url string, payload []byte) (*http.Response, error) {
-	return c.do(url, http.MethodPut, payload)
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	return c.do(ctx, url, http.MethodGet, nil)
 }
 
-func (c *Client) get(url string) (*http.Response, error) {
-	return c.do(url, http.MethodGet, nil)
+func (c *Client) delete(ctx context.Context, url string) (*http.Response, error) {
+	return c.do(ctx, url, http.MethodDelete, nil)
 }
 
-func (c *Client) delete(url string) (*http.Response, error) {
-	return c.do(url, http.MethodDelete, nil)
+// getJSON decodes the JSON document at url into v.
+func (c *Client) getJSON(ctx context.Context, url string, v interface{}) error {
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("pulse: %s\n", body)
+	}
+	return json.Unmarshal(body, v)
 }
 
 // ListIds returns a array of managed list identifiers
@@ -109,7 +187,7 @@ func (c *Client) ListIds() []string {
 	for !isComplete {
 		url := fmt.Sprintf("%s/pulseviews/api/apps/%s/managedlists/paged?limit=5&sort_by=desc&order=ASC&offset=%d&_=%d",
 			c.baseURL, c.appName, offset, time.Now().UnixNano()/int64(time.Millisecond))
-		resp, err := c.get(url)
+		resp, err := c.get(context.Background(), url)
 		if err != nil {
 			panic(err)
 		}
@@ -137,7 +215,14 @@ func (c *Client) ListIds() []string {
 // error will be non-nil of there are network issues, duplicate entries or the HTTP status
 // returned by Feedzai's API is not StatusNoContent.
 func (c *Client) UploadList(filename string, listID string) error {
+	args := map[string]string{"filename": filename, "listID": listID}
+	ctx := context.Background()
+	return c.mutate(ctx, "UploadList", args, func() error {
+		return c.uploadList(ctx, filename, listID)
+	})
+}
 
+func (c *Client) uploadList(ctx context.Context, filename string, listID string) error {
 	if filename == "" {
 		return errors.New("pulse: empty filename")
 	}
@@ -149,7 +234,7 @@ func (c *Client) UploadList(filename string, listID string) error {
 	uploadUrl := fmt.Sprintf("%s/pulseviews/api/apps/%s/managedlists/%s/managedlistitems?operation=replaceall",
 		c.baseURL, c.appName, listID)
 
-	uploadResp, err := c.upload(filename, uploadUrl)
+	uploadResp, err := c.upload(ctx, filename, uploadUrl)
 	if err != nil {
 		return err
 	}
@@ -166,13 +251,15 @@ func (c *Client) UploadList(filename string, listID string) error {
 	return nil
 }
 
+// DownloadList writes the items of the managed list listID to the CSV file filename. A response
+// other than 200 OK is returned as an error and no file is written.
 func (c *Client) DownloadList(filename string, listID string) error {
 	downloadUrl := fmt.Sprintf("%s/pulseviews/api/apps/%s/managedlists/%s/managedlistitems/csv",
 		c.baseURL, c.appName, listID)
-	return c.download(filename, downloadUrl)
+	return c.download(context.Background(), filename, downloadUrl)
 }
 
-func (c *Client) upload(filename string, url string) (*http.Response, error) {
+func (c *Client) upload(ctx context.Context, filename string, url string) (*http.Response, error) {
 	var buf bytes.Buffer
 	w := multipart.NewWriter(&buf)
 
@@ -195,7 +282,7 @@ func (c *Client) upload(filename string, url string) (*http.Response, error) {
 		return nil, err
 	}
 
-	uploadReq, err := http.NewRequest("POST", url, &buf)
+	uploadReq, err := http.NewRequestWithContext(ctx, "POST", url, &buf)
 	if err != nil {
 		return nil, err
 	}
@@ -205,12 +292,23 @@ func (c *Client) upload(filename string, url string) (*http.Response, error) {
 	return c.httpClient.Do(uploadReq)
 }
 
-func (c *Client) download(filename, url string) error {
-	resp, err := c.httpClient.Get(url)
+func (c *Client) download(ctx context.Context, filename, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("pulse: %s\n", body)
+	}
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -220,155 +318,28 @@ func (c *Client) download(filename, url string) error {
 	return err
 }
 
+// ExportApp writes the export of the application, without list items, to the zip file filename.
+// A response other than 200 OK is returned as an error and no file is written.
 func (c *Client) ExportApp(filename string) error {
-	exportUrl := fmt.Sprintf("%s/pulseviews/api/apps/%s/export?excludeItems=true", c.baseURL, c.appName)
-	return c.download(filename, exportUrl)
+	return c.exportApp(context.Background(), filename, true)
 }
 
-func (c *Client) importPlan(zipFile string) error {
-
-	// partialImportPrepare
-	partialResp, err := c.partialImportPrepare(zipFile)
-	if err != nil {
-		return err
-	}
-
-	// partialImportCheckSchemas
-	schemaRequestPlans := make([]internal.Plans, len(partialResp.Plans))
-	for i, plan := range partialResp.Plans {
-		execs := make([]internal.Executions, len(plan.Executions))
-
-		for j, exec := range plan.Executions {
-			execs[j] = internal.Executions{
-				ID: exec.ID,
-			}
-		}
-
-		schemaRequestPlans[i] = internal.Plans{
-			Executions:      execs,
-			ID:              plan.ID,
-			DestinationId:   plan.ID,
-			DestinationDesc: plan.Desc,
-		}
-	}
-
-	schemaRequest := &internal.PartialImportSchemasRequest{
-		Lists:         []internal.List{},
-		Models:        partialResp.Models,
-		Plans:         schemaRequestPlans,
-		RulesProjects: partialResp.RulesProjects,
-	}
-
-	schemaPayload, err := json.Marshal(schemaRequest)
-	if err != nil {
-		return err
-	}
-
-	checkSchemaURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportCheckSchemas/%s",
-		c.baseURL, c.appName, partialResp.ImportID)
-	checkSchemaResp, err := c.post(checkSchemaURL, schemaPayload)
-	if err != nil {
-		return err
-	}
-	defer checkSchemaResp.Body.Close()
-
-	if checkSchemaResp.StatusCode != http.StatusOK {
-		return errors.New("pulse: failed schema validation")
-	}
-
-	// partialImportCommit
-	commitURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportCommit/%s",
-		c.baseURL, c.appName, partialResp.ImportID)
-	commitResp, err := c.post(commitURL, schemaPayload)
-	if err != nil {
-		return err
-	}
-	defer commitResp.Body.Close()
-
-	if commitResp.StatusCode != http.StatusNoContent {
-		return errors.New("pulse: failed partial commit")
-	}
-	// update
-	return c.update()
-
+func (c *Client) exportApp(ctx context.Context, filename string, excludeItems bool) error {
+	exportUrl := fmt.Sprintf("%s/pulseviews/api/apps/%s/export?excludeItems=%t", c.baseURL, c.appName, excludeItems)
+	return c.download(ctx, filename, exportUrl)
 }
 
 func (c *Client) ImportRule(zipFile, workflowName, workflowElement string) error {
-	workflowElementID, err := c.getWorkflowElementID(workflowName, workflowElement)
-	if err != nil {
-		return err
-	}
-
-	partialResp, err := c.partialImportPrepare(zipFile)
-	if err != nil {
-		return err
-	}
-
-	schemaRequestRulesProjects := make([]internal.RulesProject, len(partialResp.RulesProjects))
-	for i, rulesProject := range partialResp.RulesProjects {
-		snapshots := make([]internal.Snapshot, len(rulesProject.Snapshots))
-
-		for j, snapshot := range rulesProject.Snapshots {
-			snapshots[j] = internal.Snapshot{
-				Desc: snapshot.Desc,
-				ID:   snapshot.ID,
-				WorkflowMappings: []internal.WorkflowMapping{
-					{WorkflowElementId: workflowElementID, WorkflowId: "workflow"},
-				},
-			}
-		}
-
-		schemaRequestRulesProjects[i] = internal.RulesProject{
-			ID:              rulesProject.ID,
-			DestinationDesc: rulesProject.Desc,
-			DestinationId:   rulesProject.ID,
-			Snapshots:       snapshots,
-		}
-	}
-
-	schemaRequest := &internal.PartialImportSchemasRequest{
-		Lists:         []internal.List{},
-		Models:        partialResp.Models,
-		Plans:         partialResp.Plans,
-		RulesProjects: schemaRequestRulesProjects,
+	opts := &ImportRuleOptions{
+		Default: []WorkflowTarget{{Workflow: workflowName, Element: workflowElement}},
 	}
-
-	schemaPayload, err := json.Marshal(schemaRequest)
-	if err != nil {
-		return err
-	}
-
-	checkSchemaURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportCheckSchemas/%s",
-		c.baseURL, c.appName, partialResp.ImportID)
-	checkSchemaResp, err := c.post(checkSchemaURL, schemaPayload)
-	if err != nil {
-		return err
-	}
-	defer checkSchemaResp.Body.Close()
-
-	if checkSchemaResp.StatusCode != http.StatusOK {
-		return errors.New("pulse: failed schema validation")
-	}
-
-	commitURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportCommit/%s",
-		c.baseURL, c.appName, partialResp.ImportID)
-	commitResp, err := c.post(commitURL, schemaPayload)
-	if err != nil {
-		return err
-	}
-	defer commitResp.Body.Close()
-
-	if commitResp.StatusCode != http.StatusNoContent {
-		return errors.New("pulse: failed partial commit")
-	}
-
-	return c.update()
+	return c.ImportRuleWithOptions(context.Background(), zipFile, opts)
 }
 
-func (c *Client) partialImportPrepare(zipFile string) (*internal.PartialImportPrepareResponse, error) {
+func (c *Client) partialImportPrepare(ctx context.Context, zipFile string) (*internal.PartialImportPrepareResponse, error) {
 	partialImportURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportPrepare",
 		c.baseURL, c.appName)
-	resp, err := c.upload(zipFile, partialImportURL)
+	resp, err := c.upload(ctx, zipFile, partialImportURL)
 	if err != nil {
 		return nil, err
 	}
@@ -387,71 +358,70 @@ func (c *Client) partialImportPrepare(zipFile string) (*internal.PartialImportPr
 	return &partialImportResp, nil
 }
 
-func (c *Client) getWorkflowElementID(workflowName, workflowElementName string) (string, error) {
-	rteURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/paged?limit=5&sort_by=desc&order=ASC&_=%d",
-		c.baseURL, c.appName, time.Now().UnixNano()/int64(time.Millisecond))
+func (c *Client) DeleteApp() error {
+	ctx := context.Background()
+	return c.mutate(ctx, "DeleteApp", nil, func() error {
+		return c.deleteApp(ctx)
+	})
+}
 
-	resp, err := c.get(rteURL)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+func (c *Client) deleteApp(ctx context.Context) error {
+	c.InvalidateWorkflowCache()
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s", c.baseURL, c.appName)
+	resp, err := c.delete(ctx, url)
 	if err != nil {
-		return "", err
-	}
-	var rteWorkflow internal.RteWorkflow
-	if err := json.Unmarshal(body, &rteWorkflow); err != nil {
-		return "", err
-	}
-
-	for _, item := range rteWorkflow.Items {
-		if item.Desc == workflowName {
-			for _, elem := range item.Config.Elements {
-				if elem.Desc == workflowElementName {
-					return elem.ID, nil
-				}
-			}
-		}
+		return err
 	}
+	return resp.Body.Close()
+}
 
-	return "", fmt.Errorf("pulse: workflowElementId for %s not found", workflowElementName)
+func (c *Client) ImportApp(filename string) error {
+	return c.ImportAppWithOptions(context.Background(), filename, nil)
 }
 
-func (c *Client) DeleteApp() error {
-	url := fmt.Sprintf("%s/pulseviews/api/apps/%s", c.baseURL, c.appName)
-	_, err := c.delete(url)
-	return err
+// ImportAppWithOptions imports the app export filename and starts the application. Ownership groups
+// of the export are matched to the destination groups according to opts; see MatchOwnershipGroups.
+func (c *Client) ImportAppWithOptions(ctx context.Context, filename string, opts *ImportAppOptions) error {
+	args := map[string]string{"filename": filename}
+	return c.mutate(ctx, "ImportApp", args, func() error {
+		plan, err := c.prepareAppImport(ctx, filename)
+		if err != nil {
+			return err
+		}
+		return c.commitAppImport(ctx, plan, opts)
+	})
 }
 
-func (c *Client) ImportApp(filename string) error {
+func (c *Client) prepareImport(ctx context.Context, filename string) (*internal.PrepareImportResponse, error) {
 	prepareImportURL := fmt.Sprintf("%s/pulseviews/api/apps/prepareImport", c.baseURL)
-	resp, err := c.upload(filename, prepareImportURL)
+	resp, err := c.upload(ctx, filename, prepareImportURL)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	decoded, err := base64.StdEncoding.DecodeString(string(body))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var prepareImportResp internal.PrepareImportResponse
 	if err := json.Unmarshal(decoded, &prepareImportResp); err != nil {
-		return err
+		return nil, err
 	}
+	return &prepareImportResp, nil
+}
 
+func (c *Client) commitImport(ctx context.Context, importID string, matching map[string]string) error {
 	importReq := &internal.ImportRequest{
-		ImportID: prepareImportResp.ImportID,
-		OwnershipGroupsMatching: struct {
-			ImportID string `json:"importId"`
-		}{
-			prepareImportResp.ImportID,
+		ImportID: importID,
+		OwnershipGroupsMatching: internal.OwnershipGroupsMatching{
+			ImportID: importID,
+			Matching: matching,
 		},
 	}
 
@@ -460,17 +430,33 @@ func (c *Client) ImportApp(filename string) error {
 		return err
 	}
 
+	c.InvalidateWorkflowCache()
 	url := fmt.Sprintf("%s/pulseviews/api/apps/import", c.baseURL)
 
-	err = c.submit(url, http.MethodPost, importReqPayload, http.StatusOK, "pulse: failed to import app")
+	return c.submit(ctx, url, http.MethodPost, importReqPayload, http.StatusOK, "pulse: failed to import app")
+}
+
+// cancelImport discards the app import importID prepared by prepareImport.
+func (c *Client) cancelImport(ctx context.Context, importID string) error {
+	url := fmt.Sprintf("%s/pulseviews/api/apps/importCancel/%s", c.baseURL, importID)
+	resp, err := c.post(ctx, url, []byte{})
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	return c.start()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("pulse: %s\n", body)
 }
 
-func (c *Client) lifecycle(cycle string) error {
+func (c *Client) lifecycle(ctx context.Context, cycle string, skipRecovery bool) error {
 	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/lifecycle/%s",
 		c.baseURL, c.appName, cycle)
 
@@ -478,65 +464,91 @@ func (c *Client) lifecycle(cycle string) error {
 		Async:        true,
 		FullReload:   true,
 		Rolling:      true,
-		SkipRecovery: false,
+		SkipRecovery: skipRecovery,
 	}
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return err
 	}
 
-	err = c.submit(url, http.MethodPost, payload, http.StatusOK, "pulse: failed to publish")
+	err = c.submit(ctx, url, http.MethodPost, payload, http.StatusOK, "pulse: failed to publish")
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) start() error {
-	return c.lifecycle("start")
+func (c *Client) start(ctx context.Context) error {
+	return c.lifecycle(ctx, "start", false)
 }
 
-func (c *Client) update() error {
-	return c.lifecycle("update")
+func (c *Client) update(ctx context.Context) error {
+	return c.lifecycle(ctx, "update", false)
 }
 
 func (c *Client) Restart() error {
-	body, item, err := c.getWorkflowState()
+	return c.RestartWithOptions(context.Background(), nil)
+}
+
+func (c *Client) restart(ctx context.Context, opts *RestartOptions) error {
+	body, item, err := c.getWorkflowState(ctx, defaultWorkflowID)
 	if err != nil {
 		return err
 	}
 
-	payload, err := c.validate(body, item)
+	saved := body
+	expression := item.Config.RecoveryExpression
+	if opts.RecoveryExpression != "" {
+		var w Workflow
+		if err := json.Unmarshal(body, &w); err != nil {
+			return err
+		}
+		w.Config.RecoveryExpression = opts.RecoveryExpression
+		if body, err = json.Marshal(&w); err != nil {
+			return err
+		}
+		expression = opts.RecoveryExpression
+	}
+
+	err = c.validate(ctx, body)
 	if err != nil {
 		return err
 	}
 
-	err = c.validateRestoreState(payload)
+	if !opts.SkipRecovery {
+		err = c.validateRestoreState(ctx, expression)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = c.saveSnapshot(defaultWorkflowID, "Restart", saved)
 	if err != nil {
 		return err
 	}
 
-	err = c.saveWorkflow(body)
+	err = c.saveWorkflow(ctx, defaultWorkflowID, body)
 	if err != nil {
 		return err
 	}
 
-	return c.update()
+	return c.lifecycle(ctx, "update", opts.SkipRecovery)
 }
 
-func (c *Client) submit(url string, method string, body []byte, statusCode int, errMsg string) error {
+func (c *Client) submit(ctx context.Context, url string, method string, body []byte, statusCode int, errMsg string) error {
 	var resp *http.Response
 	var err error
 	if method == http.MethodPost {
-		resp, err = c.post(url, body)
+		resp, err = c.post(ctx, url, body)
 	}
 	if method == http.MethodPut {
-		resp, err = c.put(url, body)
+		resp, err = c.put(ctx, url, body)
 	}
 
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != statusCode {
 		return errors.New(errMsg)
@@ -544,40 +556,54 @@ func (c *Client) submit(url string, method string, body []byte, statusCode int,
 	return nil
 }
 
-func (c *Client) saveWorkflow(body []byte) error {
-	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/workflow", c.baseURL, c.appName)
-	return c.submit(url, http.MethodPut, body, http.StatusOK, "pulse: failed saving workflow")
+func (c *Client) saveWorkflow(ctx context.Context, workflowID string, body []byte) error {
+	c.InvalidateWorkflowCache()
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/%s", c.baseURL, c.appName, workflowID)
+	return c.submit(ctx, url, http.MethodPut, body, http.StatusOK, "pulse: failed saving workflow")
 }
 
-func (c *Client) validateRestoreState(payload []byte) error {
+func (c *Client) validateRestoreState(ctx context.Context, recoveryExpression string) error {
 	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/validaterestorestate",
 		c.baseURL, c.appName)
 
-	return c.submit(url, http.MethodPost, payload, http.StatusNoContent, "pulse: failed validating restore state")
-}
-
-func (c *Client) validate(body []byte, item internal.Item) ([]byte, error) {
-	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/validate",
-		c.baseURL, c.appName)
+	payload, err := json.Marshal(internal.ValidateRestoreState{
+		RecoveryExpression: recoveryExpression})
+	if err != nil {
+		return err
+	}
 
-	err := c.submit(url, http.MethodPost, body, http.StatusOK, "pulse: failed validating workflow")
+	resp, err := c.post(ctx, url, payload)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer resp.Body.Close()
 
-	payload, err := json.Marshal(internal.ValidateRestoreState{
-		item.Config.RecoveryExpression})
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return payload, nil
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &RecoveryExpressionError{Expression: recoveryExpression, Messages: recoveryMessages(body)}
+	}
+	return fmt.Errorf("pulse: recovery expression validation failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
 }
 
-func (c *Client) getWorkflowState() ([]byte, internal.Item, error) {
-	rteURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/workflow?_=%d",
-		c.baseURL, c.appName, time.Now().UnixNano()/int64(time.Millisecond))
+func (c *Client) validate(ctx context.Context, body []byte) error {
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/validate",
+		c.baseURL, c.appName)
+
+	return c.submit(ctx, url, http.MethodPost, body, http.StatusOK, "pulse: failed validating workflow")
+}
+
+func (c *Client) getWorkflowState(ctx context.Context, workflowID string) ([]byte, internal.Item, error) {
+	rteURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/%s?_=%d",
+		c.baseURL, c.appName, workflowID, time.Now().UnixNano()/int64(time.Millisecond))
 
-	resp, err := c.get(rteURL)
+	resp, err := c.get(ctx, rteURL)
 	if err != nil {
 		return nil, internal.Item{}, err
 	}
@@ -596,40 +622,65 @@ func (c *Client) getWorkflowState() ([]byte, internal.Item, error) {
 func (c *Client) IsPublishInProgress() bool {
 	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/lifecycle/currentOperationProgress?_=%d",
 		c.baseURL, c.appName, time.Now().UnixNano()/int64(time.Millisecond))
-	resp, _ := c.get(url)
+	resp, err := c.get(context.Background(), url)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
 	return resp.StatusCode == http.StatusOK
 }
 
 func (c *Client) Abort() error {
-	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/lifecycle/currentOperationProgress?_=%d",
-		c.baseURL, c.appName, time.Now().UnixNano()/int64(time.Millisecond))
-	resp, err := c.get(url)
-	if err != nil {
-		return err
-	}
+	return c.audited("Abort", nil, func() error {
+		return c.abort(context.Background())
+	})
+}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+func (c *Client) abort(ctx context.Context) error {
+	progress, err := c.operationProgress(ctx)
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		var progress internal.ProgressResponse
-		if err := json.Unmarshal(body, &progress); err != nil {
-			return err
-		}
-
+	if progress != nil {
 		url := fmt.Sprintf("%s/pulseviews/api/apps/%s/lifecycle/cancel/%s",
 			c.baseURL, c.appName, progress.OperationId)
 
-		resp, err = c.post(url, []byte{})
+		resp, err := c.post(ctx, url, []byte{})
 		if err != nil {
 			return err
 		}
+		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
 			return errors.New("pulse: failed aborting publish")
 		}
 	}
 	return nil
 }
+
+// operationProgress returns the progress of the current lifecycle operation,
+// or nil if Pulse reports none.
+func (c *Client) operationProgress(ctx context.Context) (*internal.ProgressResponse, error) {
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/lifecycle/currentOperationProgress?_=%d",
+		c.baseURL, c.appName, time.Now().UnixNano()/int64(time.Millisecond))
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	var progress internal.ProgressResponse
+	if err := json.Unmarshal(body, &progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
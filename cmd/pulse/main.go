@@ -0,0 +1,227 @@
+// Command pulse works with Feedzai Pulse export archives.
+//
+// Usage:
+//
+//	pulse diff a.zip b.zip
+//	pulse render [-mermaid] [-diff before.zip] export.zip [workflow]
+//	pulse build [-o rules.zip] project.yaml...
+//	pulse decompile [-json] [-dir directory] export.zip
+//
+// diff prints the rules, conditions, lists, workflow elements, connections and outcomes
+// that differ between two exports, and exits with status 1 when they differ.
+//
+// render prints a workflow of an export as a Graphviz DOT digraph, or a Mermaid flowchart.
+// With -diff, the elements and connections changed since the before export are highlighted.
+//
+// build turns rules projects written in YAML or JSON into a partial export accepted by
+// ImportRule. decompile does the reverse, printing the rules projects of an export, or writing
+// them to directory, one file per project.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	pulse "github.com/jcaberio/go-pulse"
+	"github.com/jcaberio/go-pulse/archive"
+	"github.com/jcaberio/go-pulse/graph"
+	"github.com/jcaberio/go-pulse/rules"
+)
+
+const usage = `usage: pulse <command> [arguments]
+
+commands:
+  diff a.zip b.zip                  report the differences between two exports
+  render [flags] export.zip [name]  draw a workflow of an export
+  build [flags] project.yaml...     build rules projects into a partial export
+  decompile [flags] export.zip      print the rules projects of an export as YAML
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "diff":
+		err = diff(os.Args[2:])
+	case "render":
+		err = render(os.Args[2:])
+	case "build":
+		err = build(os.Args[2:])
+	case "decompile":
+		err = decompile(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err == errDifferent {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pulse:", err)
+		os.Exit(2)
+	}
+}
+
+var errDifferent = errors.New("exports differ")
+
+func diff(args []string) error {
+	if len(args) != 2 {
+		return errors.New("diff takes two export files")
+	}
+
+	a, err := archive.Open(args[0])
+	if err != nil {
+		return err
+	}
+	b, err := archive.Open(args[1])
+	if err != nil {
+		return err
+	}
+
+	d := archive.DiffExports(a, b)
+	if _, err := d.WriteTo(os.Stdout); err != nil {
+		return err
+	}
+	if !d.Empty() {
+		return errDifferent
+	}
+	return nil
+}
+
+func render(args []string) error {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	mermaid := flags.Bool("mermaid", false, "write a Mermaid flowchart instead of a DOT digraph")
+	before := flags.String("diff", "", "highlight the changes since this export")
+	flags.Parse(args)
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		return errors.New("render takes an export file and an optional workflow name")
+	}
+	name := flags.Arg(1)
+
+	w, err := readWorkflow(flags.Arg(0), name)
+	if err != nil {
+		return err
+	}
+
+	var opts *graph.RenderOptions
+	if *before != "" {
+		old, err := readWorkflow(*before, name)
+		if err != nil {
+			return err
+		}
+		w, opts = graph.Diff(old, w)
+	}
+
+	if *mermaid {
+		return graph.WriteMermaid(os.Stdout, w, opts)
+	}
+	return graph.WriteDOT(os.Stdout, w, opts)
+}
+
+func build(args []string) error {
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	output := flags.String("o", "rules.zip", "name of the partial export to write")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		return errors.New("build takes one or more project files")
+	}
+
+	projects := make([]*rules.Project, flags.NArg())
+	for i, filename := range flags.Args() {
+		p, err := rules.ParseFile(filename)
+		if err != nil {
+			return err
+		}
+		projects[i] = p
+	}
+	return rules.BuildFile(*output, projects...)
+}
+
+func decompile(args []string) error {
+	flags := flag.NewFlagSet("decompile", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "write JSON instead of YAML")
+	dir := flags.String("dir", "", "write one file per project to this directory")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return errors.New("decompile takes an export file")
+	}
+
+	projects, err := rules.DecompileFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *dir != "" {
+		for _, p := range projects {
+			if !rules.ValidID(p.ID) {
+				return fmt.Errorf("project id %q cannot name a file", p.ID)
+			}
+		}
+		if err := os.MkdirAll(*dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	marshal, ext := rules.Marshal, ".yaml"
+	if *asJSON {
+		marshal, ext = rules.MarshalJSON, ".json"
+	}
+	for i, p := range projects {
+		data, err := marshal(p)
+		if err != nil {
+			return err
+		}
+		if *dir != "" {
+			if err := ioutil.WriteFile(filepath.Join(*dir, p.ID+ext), data, 0644); err != nil {
+				return err
+			}
+			continue
+		}
+		if i > 0 && !*asJSON {
+			fmt.Println("---")
+		}
+		os.Stdout.Write(data)
+	}
+	return nil
+}
+
+// readWorkflow returns the workflow identified by name in the export filename, or its only
+// workflow when name is empty.
+func readWorkflow(filename, name string) (*pulse.Workflow, error) {
+	a, err := archive.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var entity *archive.Entity
+	if name != "" {
+		entity = a.Entity(archive.KindWorkflow, name)
+	} else if workflows := a.Kind(archive.KindWorkflow); len(workflows) == 1 {
+		entity = workflows[0]
+	} else if len(workflows) > 1 {
+		return nil, fmt.Errorf("%s holds %d workflows, name one", filename, len(workflows))
+	}
+	if entity == nil {
+		return nil, fmt.Errorf("workflow %s not found in %s", name, filename)
+	}
+
+	data, err := json.Marshal(entity.Object)
+	if err != nil {
+		return nil, err
+	}
+	var w pulse.Workflow
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
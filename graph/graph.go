@@ -0,0 +1,351 @@
+// Package graph analyses the topology of Pulse RTE workflows: cycles, orphaned and unreachable
+// elements, dangling connections, topological order and paths between elements.
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	pulse "github.com/jcaberio/go-pulse"
+)
+
+// Graph is the directed graph of the elements of a workflow, connected from source to sink.
+type Graph struct {
+	ids        []string
+	elements   map[string]*pulse.WorkflowElement
+	out        map[string][]string
+	in         map[string][]string
+	dangling   []pulse.WorkflowConnection
+	duplicates []string
+}
+
+// New builds the graph of w. Connections referencing unknown elements are not part of the
+// graph; they are reported by Dangling. Only the first element with a given ID is part of the
+// graph; the others are reported by Duplicates.
+func New(w *pulse.Workflow) *Graph {
+	g := &Graph{
+		elements: make(map[string]*pulse.WorkflowElement),
+		out:      make(map[string][]string),
+		in:       make(map[string][]string),
+	}
+
+	for i := range w.Config.Elements {
+		element := &w.Config.Elements[i]
+		if g.elements[element.ID] != nil {
+			g.duplicates = append(g.duplicates, element.ID)
+			continue
+		}
+		g.ids = append(g.ids, element.ID)
+		g.elements[element.ID] = element
+	}
+
+	for _, connection := range w.Config.Connections {
+		if g.elements[connection.SourceID] == nil || g.elements[connection.SinkID] == nil {
+			g.dangling = append(g.dangling, connection)
+			continue
+		}
+		g.out[connection.SourceID] = append(g.out[connection.SourceID], connection.SinkID)
+		g.in[connection.SinkID] = append(g.in[connection.SinkID], connection.SourceID)
+	}
+
+	return g
+}
+
+// Elements returns the identifiers of the elements, in workflow order.
+func (g *Graph) Elements() []string {
+	return append([]string(nil), g.ids...)
+}
+
+// Element returns the element with the given ID, or nil.
+func (g *Graph) Element(id string) *pulse.WorkflowElement {
+	return g.elements[id]
+}
+
+// Successors returns the elements id is connected to.
+func (g *Graph) Successors(id string) []string {
+	return append([]string(nil), g.out[id]...)
+}
+
+// Predecessors returns the elements connected to id.
+func (g *Graph) Predecessors(id string) []string {
+	return append([]string(nil), g.in[id]...)
+}
+
+// Dangling returns the connections whose source or sink is not an element of the workflow.
+func (g *Graph) Dangling() []pulse.WorkflowConnection {
+	return append([]pulse.WorkflowConnection(nil), g.dangling...)
+}
+
+// Duplicates returns the IDs shared by several elements, once per element left out of the graph.
+func (g *Graph) Duplicates() []string {
+	return append([]string(nil), g.duplicates...)
+}
+
+// Orphans returns the elements without any connection.
+func (g *Graph) Orphans() []string {
+	var orphans []string
+	for _, id := range g.ids {
+		if len(g.in[id]) == 0 && len(g.out[id]) == 0 {
+			orphans = append(orphans, id)
+		}
+	}
+	return orphans
+}
+
+// Entries returns the elements events enter the workflow through: elements whose type names an
+// input or a source, or else the elements without predecessors.
+func (g *Graph) Entries() []string {
+	var entries []string
+	for _, id := range g.ids {
+		t := strings.ToLower(g.elements[id].Type)
+		if strings.Contains(t, "input") || strings.Contains(t, "source") {
+			entries = append(entries, id)
+		}
+	}
+	if len(entries) > 0 {
+		return entries
+	}
+
+	for _, id := range g.ids {
+		if len(g.in[id]) == 0 {
+			entries = append(entries, id)
+		}
+	}
+	return entries
+}
+
+// Unreachable returns the elements that cannot be reached from the given elements, or from
+// Entries when none is given.
+func (g *Graph) Unreachable(from ...string) []string {
+	if len(from) == 0 {
+		from = g.Entries()
+	}
+	reached := g.walk(from, g.out)
+	for _, id := range from {
+		reached[id] = true
+	}
+
+	var unreachable []string
+	for _, id := range g.ids {
+		if !reached[id] {
+			unreachable = append(unreachable, id)
+		}
+	}
+	return unreachable
+}
+
+// WithoutOutcomes returns the elements that produce no outcome.
+func (g *Graph) WithoutOutcomes() []string {
+	var ids []string
+	for _, id := range g.ids {
+		if len(g.elements[id].OutcomeIDs) == 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Upstream returns the elements feeding id, directly or not, in workflow order.
+func (g *Graph) Upstream(id string) []string {
+	return g.ordered(g.walk([]string{id}, g.in))
+}
+
+// Downstream returns the elements fed by id, directly or not, in workflow order.
+func (g *Graph) Downstream(id string) []string {
+	return g.ordered(g.walk([]string{id}, g.out))
+}
+
+// walk returns the elements reachable from start following edges, start excluded unless
+// it is on a cycle.
+func (g *Graph) walk(start []string, edges map[string][]string) map[string]bool {
+	seen := make(map[string]bool)
+	stack := append([]string(nil), start...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range edges[id] {
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return seen
+}
+
+func (g *Graph) ordered(set map[string]bool) []string {
+	var ids []string
+	for _, id := range g.ids {
+		if set[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Paths returns every path without repeated element going from the element from to the
+// element to.
+func (g *Graph) Paths(from, to string) [][]string {
+	var paths [][]string
+	onPath := make(map[string]bool)
+
+	var visit func(id string, path []string)
+	visit = func(id string, path []string) {
+		path = append(path, id)
+		if id == to {
+			paths = append(paths, append([]string(nil), path...))
+			return
+		}
+		onPath[id] = true
+		for _, next := range g.out[id] {
+			if !onPath[next] {
+				visit(next, path)
+			}
+		}
+		onPath[id] = false
+	}
+
+	if g.elements[from] != nil && g.elements[to] != nil {
+		visit(from, nil)
+	}
+	return paths
+}
+
+// Cycles returns the strongly connected components of the graph that contain a cycle, each
+// in workflow order.
+func (g *Graph) Cycles() [][]string {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	next := 0
+
+	var connect func(id string)
+	connect = func(id string) {
+		index[id] = next
+		low[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, sink := range g.out[id] {
+			if _, visited := index[sink]; !visited {
+				connect(sink)
+				if low[sink] < low[id] {
+					low[id] = low[sink]
+				}
+			} else if onStack[sink] && index[sink] < low[id] {
+				low[id] = index[sink]
+			}
+		}
+
+		if low[id] != index[id] {
+			return
+		}
+		component := make(map[string]bool)
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component[top] = true
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.selfLoop(id) {
+			cycles = append(cycles, g.ordered(component))
+		}
+	}
+
+	for _, id := range g.ids {
+		if _, visited := index[id]; !visited {
+			connect(id)
+		}
+	}
+	return cycles
+}
+
+func (g *Graph) selfLoop(id string) bool {
+	for _, sink := range g.out[id] {
+		if sink == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CycleError is returned by TopologicalOrder when the workflow has cycles.
+type CycleError struct {
+	Cycles [][]string
+}
+
+func (e *CycleError) Error() string {
+	cycles := make([]string, len(e.Cycles))
+	for i, cycle := range e.Cycles {
+		cycles[i] = strings.Join(cycle, ", ")
+	}
+	return fmt.Sprintf("graph: workflow has %d cycles: [%s]", len(e.Cycles), strings.Join(cycles, "], ["))
+}
+
+// TopologicalOrder returns the elements ordered so that every element comes after the elements
+// feeding it. Elements with no order between them keep their workflow order.
+func (g *Graph) TopologicalOrder() ([]string, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &CycleError{Cycles: cycles}
+	}
+
+	indegree := make(map[string]int, len(g.ids))
+	for _, id := range g.ids {
+		indegree[id] = len(g.in[id])
+	}
+
+	order := make([]string, 0, len(g.ids))
+	done := make(map[string]bool, len(g.ids))
+	for len(order) < len(g.ids) {
+		progress := false
+		for _, id := range g.ids {
+			if done[id] || indegree[id] > 0 {
+				continue
+			}
+			done[id] = true
+			order = append(order, id)
+			for _, sink := range g.out[id] {
+				indegree[sink]--
+			}
+			progress = true
+			break
+		}
+		if !progress {
+			return nil, fmt.Errorf("graph: no element left without predecessors after %d of %d elements", len(order), len(g.ids))
+		}
+	}
+	return order, nil
+}
+
+// Report gathers the findings of the analysis of a workflow.
+type Report struct {
+	Order           []string
+	Cycles          [][]string
+	Orphans         []string
+	Unreachable     []string
+	Dangling        []pulse.WorkflowConnection
+	Duplicates      []string
+	WithoutOutcomes []string
+}
+
+// Analyze builds the graph of w and reports its findings. Order is empty when the workflow has
+// cycles.
+func Analyze(w *pulse.Workflow) *Report {
+	g := New(w)
+	order, _ := g.TopologicalOrder()
+	return &Report{
+		Order:           order,
+		Cycles:          g.Cycles(),
+		Orphans:         g.Orphans(),
+		Unreachable:     g.Unreachable(),
+		Dangling:        g.Dangling(),
+		Duplicates:      g.Duplicates(),
+		WithoutOutcomes: g.WithoutOutcomes(),
+	}
+}
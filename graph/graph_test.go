@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	pulse "github.com/jcaberio/go-pulse"
+)
+
+// workflow returns a workflow with the given element IDs and connections, each given as a
+// source and a sink ID.
+func workflow(ids []string, connections ...[2]string) *pulse.Workflow {
+	w := &pulse.Workflow{}
+	for _, id := range ids {
+		w.Config.Elements = append(w.Config.Elements, pulse.WorkflowElement{ID: id})
+	}
+	for _, c := range connections {
+		w.Config.Connections = append(w.Config.Connections, pulse.WorkflowConnection{SourceID: c[0], SinkID: c[1]})
+	}
+	return w
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		w           *pulse.Workflow
+		want        []string
+		duplicates  []string
+		cycleErrors bool
+	}{
+		{
+			name: "empty",
+			w:    workflow(nil),
+			want: []string{},
+		},
+		{
+			name: "chain",
+			w:    workflow([]string{"c", "b", "a"}, [2]string{"a", "b"}, [2]string{"b", "c"}),
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "unrelated elements keep workflow order",
+			w:    workflow([]string{"x", "b", "a"}, [2]string{"a", "b"}),
+			want: []string{"x", "a", "b"},
+		},
+		{
+			name: "diamond",
+			w:    workflow([]string{"d", "c", "b", "a"}, [2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"b", "d"}, [2]string{"c", "d"}),
+			want: []string{"a", "c", "b", "d"},
+		},
+		{
+			name:       "duplicate ids",
+			w:          workflow([]string{"a", "b", "a"}, [2]string{"a", "b"}),
+			want:       []string{"a", "b"},
+			duplicates: []string{"a"},
+		},
+		{
+			name:       "duplicate sink",
+			w:          workflow([]string{"a", "b", "b"}, [2]string{"a", "b"}, [2]string{"a", "b"}),
+			want:       []string{"a", "b"},
+			duplicates: []string{"b"},
+		},
+		{
+			name: "dangling connections",
+			w:    workflow([]string{"a"}, [2]string{"a", "missing"}),
+			want: []string{"a"},
+		},
+		{
+			name:        "cycle",
+			w:           workflow([]string{"a", "b"}, [2]string{"a", "b"}, [2]string{"b", "a"}),
+			cycleErrors: true,
+		},
+		{
+			name:        "self loop",
+			w:           workflow([]string{"a"}, [2]string{"a", "a"}),
+			cycleErrors: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New(test.w)
+			order, err := g.TopologicalOrder()
+			if test.cycleErrors {
+				if _, ok := err.(*CycleError); !ok {
+					t.Fatalf("got error %v, want a cycle error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(order, test.want) {
+				t.Errorf("got order %q, want %q", order, test.want)
+			}
+			if got := g.Duplicates(); !reflect.DeepEqual(got, test.duplicates) {
+				t.Errorf("got duplicates %q, want %q", got, test.duplicates)
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	w := workflow([]string{"in", "a", "b", "lonely", "c"},
+		[2]string{"in", "a"}, [2]string{"a", "b"}, [2]string{"c", "b"}, [2]string{"b", "gone"})
+	w.Config.Elements[0].Type = "input"
+	w.Config.Elements[2].OutcomeIDs = []string{"score"}
+
+	r := Analyze(w)
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"order", r.Order, []string{"in", "a", "lonely", "c", "b"}},
+		{"orphans", r.Orphans, []string{"lonely"}},
+		{"unreachable", r.Unreachable, []string{"lonely", "c"}},
+		{"dangling", len(r.Dangling), 1},
+		{"without outcomes", r.WithoutOutcomes, []string{"in", "a", "lonely", "c"}},
+		{"upstream", New(w).Upstream("b"), []string{"in", "a", "c"}},
+		{"downstream", New(w).Downstream("in"), []string{"a", "b"}},
+		{"paths", New(w).Paths("in", "b"), [][]string{{"in", "a", "b"}}},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	pulse "github.com/jcaberio/go-pulse"
+)
+
+// Highlight marks an element or a connection of a rendered workflow.
+type Highlight string
+
+// Highlights.
+const (
+	Added   Highlight = "added"
+	Removed Highlight = "removed"
+	Changed Highlight = "changed"
+)
+
+// RenderOptions configures the rendering of a workflow.
+type RenderOptions struct {
+	// Elements highlights elements by ID.
+	Elements map[string]Highlight
+	// Connections highlights connections by ID.
+	Connections map[string]Highlight
+}
+
+var dotStyles = map[Highlight]string{
+	Added:   `color="#2a8f2a", fillcolor="#d9f5d9", style="rounded,filled"`,
+	Removed: `color="#b52a2a", fillcolor="#f7d9d9", style="rounded,filled,dashed"`,
+	Changed: `color="#b5892a", fillcolor="#fff2c7", style="rounded,filled"`,
+}
+
+var dotEdgeStyles = map[Highlight]string{
+	Added:   `color="#2a8f2a", penwidth=2`,
+	Removed: `color="#b52a2a", style="dashed"`,
+	Changed: `color="#b5892a", penwidth=2`,
+}
+
+var mermaidEdgeStyles = map[Highlight]string{
+	Added:   "stroke:#2a8f2a,stroke-width:2px",
+	Removed: "stroke:#b52a2a,stroke-dasharray:4",
+	Changed: "stroke:#b5892a,stroke-width:2px",
+}
+
+var mermaidStyles = map[Highlight]string{
+	Added:   "fill:#d9f5d9,stroke:#2a8f2a",
+	Removed: "fill:#f7d9d9,stroke:#b52a2a,stroke-dasharray:4",
+	Changed: "fill:#fff2c7,stroke:#b5892a",
+}
+
+// WriteDOT writes w as a Graphviz DOT digraph. Elements are labelled with their name, type and
+// outcomes, connections with their filter template.
+func WriteDOT(out io.Writer, w *pulse.Workflow, opts *RenderOptions) error {
+	if opts == nil {
+		opts = &RenderOptions{}
+	}
+	b := bufio.NewWriter(out)
+
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(workflowName(w)))
+	fmt.Fprintln(b, "\trankdir=LR;")
+	fmt.Fprintln(b, `	node [shape=box, style="rounded"];`)
+
+	for _, element := range w.Config.Elements {
+		attrs := "label=" + dotQuote(elementLabel(element))
+		if style, ok := dotStyles[opts.Elements[element.ID]]; ok {
+			attrs += ", " + style
+		}
+		fmt.Fprintf(b, "\t%s [%s];\n", dotQuote(element.ID), attrs)
+	}
+
+	for _, connection := range w.Config.Connections {
+		var attrs []string
+		if label := connectionLabel(connection); label != "" {
+			attrs = append(attrs, "label="+dotQuote(label))
+		}
+		if style, ok := dotEdgeStyles[opts.Connections[connection.ID]]; ok {
+			attrs = append(attrs, style)
+		}
+		fmt.Fprintf(b, "\t%s -> %s", dotQuote(connection.SourceID), dotQuote(connection.SinkID))
+		if len(attrs) > 0 {
+			fmt.Fprintf(b, " [%s]", strings.Join(attrs, ", "))
+		}
+		fmt.Fprintln(b, ";")
+	}
+
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// WriteMermaid writes w as a Mermaid flowchart, suitable for Markdown documents.
+func WriteMermaid(out io.Writer, w *pulse.Workflow, opts *RenderOptions) error {
+	if opts == nil {
+		opts = &RenderOptions{}
+	}
+	b := bufio.NewWriter(out)
+
+	// Mermaid node identifiers are restricted, elements are numbered instead.
+	nodes := make(map[string]string, len(w.Config.Elements))
+	node := func(id string) string {
+		if n, ok := nodes[id]; ok {
+			return n
+		}
+		n := fmt.Sprintf("n%d", len(nodes))
+		nodes[id] = n
+		return n
+	}
+
+	fmt.Fprintln(b, "flowchart LR")
+	for _, element := range w.Config.Elements {
+		fmt.Fprintf(b, "    %s[\"%s\"]\n", node(element.ID), mermaidEscape(elementLabel(element)))
+	}
+
+	// Endpoints of dangling connections are drawn with their identifier.
+	for _, connection := range w.Config.Connections {
+		for _, id := range []string{connection.SourceID, connection.SinkID} {
+			if _, ok := nodes[id]; !ok {
+				fmt.Fprintf(b, "    %s[\"%s\"]\n", node(id), mermaidEscape(id))
+			}
+		}
+	}
+
+	for i, connection := range w.Config.Connections {
+		arrow := "-->"
+		if label := connectionLabel(connection); label != "" {
+			arrow = fmt.Sprintf("-->|\"%s\"|", mermaidEscape(label))
+		}
+		fmt.Fprintf(b, "    %s %s %s\n", node(connection.SourceID), arrow, node(connection.SinkID))
+		if highlight := opts.Connections[connection.ID]; highlight != "" {
+			fmt.Fprintf(b, "    linkStyle %d %s\n", i, mermaidEdgeStyles[highlight])
+		}
+	}
+
+	for _, highlight := range []Highlight{Added, Removed, Changed} {
+		var ids []string
+		for _, element := range w.Config.Elements {
+			if opts.Elements[element.ID] == highlight {
+				ids = append(ids, node(element.ID))
+			}
+		}
+		if len(ids) > 0 {
+			fmt.Fprintf(b, "    classDef %s %s\n", highlight, mermaidStyles[highlight])
+			fmt.Fprintf(b, "    class %s %s\n", strings.Join(ids, ","), highlight)
+		}
+	}
+
+	return b.Flush()
+}
+
+// Diff merges the workflows before and after into a single workflow holding the elements and
+// connections of both, and highlights what was added, removed or changed.
+func Diff(before, after *pulse.Workflow) (*pulse.Workflow, *RenderOptions) {
+	merged := *after
+	merged.Config.Elements = append([]pulse.WorkflowElement(nil), after.Config.Elements...)
+	merged.Config.Connections = append([]pulse.WorkflowConnection(nil), after.Config.Connections...)
+	opts := &RenderOptions{
+		Elements:    make(map[string]Highlight),
+		Connections: make(map[string]Highlight),
+	}
+
+	oldElements := make(map[string]pulse.WorkflowElement)
+	for _, element := range before.Config.Elements {
+		oldElements[element.ID] = element
+	}
+	newElements := make(map[string]bool)
+	for _, element := range after.Config.Elements {
+		newElements[element.ID] = true
+		old, ok := oldElements[element.ID]
+		if !ok {
+			opts.Elements[element.ID] = Added
+		} else if !sameJSON(old, element) {
+			opts.Elements[element.ID] = Changed
+		}
+	}
+	for _, element := range before.Config.Elements {
+		if !newElements[element.ID] {
+			merged.Config.Elements = append(merged.Config.Elements, element)
+			opts.Elements[element.ID] = Removed
+		}
+	}
+
+	oldConnections := make(map[string]pulse.WorkflowConnection)
+	for _, connection := range before.Config.Connections {
+		oldConnections[connection.ID] = connection
+	}
+	newConnections := make(map[string]bool)
+	for _, connection := range after.Config.Connections {
+		newConnections[connection.ID] = true
+		old, ok := oldConnections[connection.ID]
+		if !ok {
+			opts.Connections[connection.ID] = Added
+		} else if !sameJSON(old, connection) {
+			opts.Connections[connection.ID] = Changed
+		}
+	}
+	for _, connection := range before.Config.Connections {
+		if !newConnections[connection.ID] {
+			merged.Config.Connections = append(merged.Config.Connections, connection)
+			opts.Connections[connection.ID] = Removed
+		}
+	}
+
+	return &merged, opts
+}
+
+// volatileFields are the audit properties ignored at any depth when comparing elements and
+// connections.
+var volatileFields = map[string]bool{
+	"createdAt": true,
+	"createdBy": true,
+	"updatedAt": true,
+	"updatedBy": true,
+}
+
+// sameJSON tells whether a and b have the same JSON representation, ignoring volatileFields.
+// The top level id is ignored too, as elements and connections are matched by ID beforehand;
+// nested ids, which reference schemas, models or lists, are compared.
+func sameJSON(a, b interface{}) bool {
+	va, errA := stableJSON(a)
+	vb, errB := stableJSON(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func stableJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		delete(object, "id")
+	}
+	return stripVolatile(value), nil
+}
+
+func stripVolatile(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if volatileFields[key] {
+				delete(v, key)
+			} else {
+				v[key] = stripVolatile(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = stripVolatile(child)
+		}
+	}
+	return value
+}
+
+func workflowName(w *pulse.Workflow) string {
+	if w.Desc != "" {
+		return w.Desc
+	}
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.ID
+}
+
+func elementLabel(element pulse.WorkflowElement) string {
+	name := element.Desc
+	if name == "" {
+		name = element.ID
+	}
+	lines := []string{name}
+	if element.Type != "" {
+		lines = append(lines, "«"+element.Type+"»")
+	}
+	if len(element.OutcomeIDs) > 0 {
+		lines = append(lines, "→ "+strings.Join(element.OutcomeIDs, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func connectionLabel(connection pulse.WorkflowConnection) string {
+	if connection.Filter == nil {
+		return ""
+	}
+	if connection.Filter.Template != "" {
+		return connection.Filter.Template
+	}
+	return strings.Join(connection.Filter.Fields, ", ")
+}
+
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", "<br/>", "|", "#124;").Replace(s)
+}
@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	pulse "github.com/jcaberio/go-pulse"
+)
+
+func TestDiff(t *testing.T) {
+	element := func(id, configuration string) pulse.WorkflowElement {
+		return pulse.WorkflowElement{ID: id, Type: "rules", Configuration: json.RawMessage(configuration)}
+	}
+	tests := []struct {
+		name        string
+		before      []pulse.WorkflowElement
+		after       []pulse.WorkflowElement
+		elements    map[string]Highlight
+		connections map[string]Highlight
+	}{
+		{
+			name:     "unchanged",
+			before:   []pulse.WorkflowElement{element("a", `{"threshold": 1}`)},
+			after:    []pulse.WorkflowElement{element("a", `{"threshold": 1}`)},
+			elements: map[string]Highlight{},
+		},
+		{
+			name:     "audit fields",
+			before:   []pulse.WorkflowElement{element("a", `{"threshold": 1, "updatedAt": 1, "updatedBy": "x"}`)},
+			after:    []pulse.WorkflowElement{element("a", `{"threshold": 1, "updatedAt": 2, "updatedBy": "y", "createdAt": 3}`)},
+			elements: map[string]Highlight{},
+		},
+		{
+			name:     "nested audit fields",
+			before:   []pulse.WorkflowElement{element("a", `{"rules": [{"id": "1", "score": 5, "updatedAt": 1}]}`)},
+			after:    []pulse.WorkflowElement{element("a", `{"rules": [{"id": "1", "score": 5, "updatedAt": 2}]}`)},
+			elements: map[string]Highlight{},
+		},
+		{
+			name:     "changed reference",
+			before:   []pulse.WorkflowElement{element("a", `{"schema": {"id": "input"}}`)},
+			after:    []pulse.WorkflowElement{element("a", `{"schema": {"id": "input2"}}`)},
+			elements: map[string]Highlight{"a": Changed},
+		},
+		{
+			name:     "changed configuration",
+			before:   []pulse.WorkflowElement{element("a", `{"rules": [{"id": "1", "score": 5}]}`)},
+			after:    []pulse.WorkflowElement{element("a", `{"rules": [{"id": "1", "score": 6}]}`)},
+			elements: map[string]Highlight{"a": Changed},
+		},
+		{
+			name:     "key order",
+			before:   []pulse.WorkflowElement{element("a", `{"x": 1, "y": 2}`)},
+			after:    []pulse.WorkflowElement{element("a", `{"y": 2, "x": 1}`)},
+			elements: map[string]Highlight{},
+		},
+		{
+			name:     "added and removed",
+			before:   []pulse.WorkflowElement{element("a", ``)},
+			after:    []pulse.WorkflowElement{element("b", ``)},
+			elements: map[string]Highlight{"a": Removed, "b": Added},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := &pulse.Workflow{Config: pulse.WorkflowConfig{Elements: test.before}}
+			after := &pulse.Workflow{Config: pulse.WorkflowConfig{Elements: test.after}}
+			_, opts := Diff(before, after)
+			if !reflect.DeepEqual(opts.Elements, test.elements) {
+				t.Errorf("got highlights %v, want %v", opts.Elements, test.elements)
+			}
+		})
+	}
+}
+
+func TestDiffConnections(t *testing.T) {
+	connection := func(id, sink, template string) pulse.WorkflowConnection {
+		c := pulse.WorkflowConnection{ID: id, SourceID: "a", SinkID: sink}
+		if template != "" {
+			c.Filter = &pulse.ConnectionFilter{Template: template}
+		}
+		return c
+	}
+	before := workflow([]string{"a", "b", "c"})
+	before.Config.Connections = []pulse.WorkflowConnection{connection("1", "b", ""), connection("2", "c", "x > 1")}
+	after := workflow([]string{"a", "b", "c"})
+	after.Config.Connections = []pulse.WorkflowConnection{connection("2", "c", "x > 2"), connection("3", "b", "")}
+
+	_, opts := Diff(before, after)
+	want := map[string]Highlight{"1": Removed, "2": Changed, "3": Added}
+	if !reflect.DeepEqual(opts.Connections, want) {
+		t.Errorf("got highlights %v, want %v", opts.Connections, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	w := workflow([]string{"a", "b"}, [2]string{"a", "b"})
+	w.Desc = "Fraud"
+	opts := &RenderOptions{Elements: map[string]Highlight{"b": Added}}
+
+	tests := []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		want  []string
+	}{
+		{"dot", func(b *bytes.Buffer) error { return WriteDOT(b, w, opts) }, []string{`digraph "Fraud" {`, `"a" -> "b";`, `"b" [label=`}},
+		{"mermaid", func(b *bytes.Buffer) error { return WriteMermaid(b, w, opts) }, []string{"flowchart", "classDef added"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := test.write(&b); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, b.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package pulse
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// ErrOperationInProgress is returned by mutating calls when a lifecycle operation is running
+// on the application and the client is configured with GuardFail, or when GuardWait or
+// GuardAbort gave up waiting.
+var ErrOperationInProgress = errors.New("pulse: operation in progress")
+
+// GuardPolicy tells the client how to handle a mutating call made while a lifecycle
+// operation (publish, start, restart...) is running.
+type GuardPolicy int
+
+const (
+	// GuardNone does not check for running operations.
+	GuardNone GuardPolicy = iota
+	// GuardWait waits for the running operation to finish.
+	GuardWait
+	// GuardFail returns ErrOperationInProgress.
+	GuardFail
+	// GuardAbort cancels the running operation and waits for it to finish.
+	GuardAbort
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// appLocks serializes mutating calls per application within the process.
+type appLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (l *appLocks) lock(app string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+	m, ok := l.locks[app]
+	if !ok {
+		m = &sync.Mutex{}
+		l.locks[app] = m
+	}
+	l.mu.Unlock()
+
+	m.Lock()
+	return m.Unlock
+}
+
+// processLocks is the lock registry shared by every client of the process, so that two clients
+// of the same application never mutate it concurrently.
+var processLocks = &appLocks{}
+
+// mutate runs fn holding the application lock, after applying the guard policy,
+// and records the call in the audit sink.
+func (c *Client) mutate(ctx context.Context, operation string, args map[string]string, fn func() error) error {
+	return c.audited(operation, args, func() error {
+		unlock := processLocks.lock(c.baseURL + "/" + c.appName)
+		defer unlock()
+
+		if err := c.checkGuard(ctx); err != nil {
+			return err
+		}
+		return fn()
+	})
+}
+
+func (c *Client) checkGuard(ctx context.Context) error {
+	if c.guard == GuardNone {
+		return nil
+	}
+
+	running, err := c.operationRunning(ctx)
+	if err != nil || !running {
+		return err
+	}
+
+	switch c.guard {
+	case GuardFail:
+		return ErrOperationInProgress
+	case GuardAbort:
+		if err := c.abort(ctx); err != nil {
+			return err
+		}
+	}
+
+	if c.guardWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.guardWait)
+		defer cancel()
+	}
+	if err := c.waitForOperation(ctx); err != nil {
+		if err == context.DeadlineExceeded {
+			return ErrOperationInProgress
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Client) operationRunning(ctx context.Context) (bool, error) {
+	progress, err := c.operationProgress(ctx)
+	if err != nil {
+		return false, err
+	}
+	return progress != nil && !progress.HasFinished, nil
+}
+
+// waitForOperation polls the current lifecycle operation until it finishes or ctx is done.
+func (c *Client) waitForOperation(ctx context.Context) error {
+	ticker := time.NewTicker(c.pollEvery)
+	defer ticker.Stop()
+
+	for {
+		running, err := c.operationRunning(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if !running {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForPublish waits for the lifecycle operation following the operation previousID, the one
+// running or last reported before a publish was requested, to finish, and returns its last
+// reported progress. Operations still reported as previousID are ignored, so that an asynchronous
+// publish that Pulse has not registered yet is not missed. It waits until ctx is done.
+func (c *Client) waitForPublish(ctx context.Context, previousID string) (*internal.ProgressResponse, error) {
+	ticker := time.NewTicker(c.pollEvery)
+	defer ticker.Stop()
+
+	var last *internal.ProgressResponse
+	for {
+		progress, err := c.operationProgress(ctx)
+		if err != nil {
+			return last, err
+		}
+		if progress != nil && progress.OperationId != previousID {
+			last = progress
+			if progress.HasFinished {
+				return last, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// currentOperationID returns the identifier of the current or last lifecycle operation, or an
+// empty string if Pulse reports none.
+func (c *Client) currentOperationID(ctx context.Context) (string, error) {
+	progress, err := c.operationProgress(ctx)
+	if err != nil || progress == nil {
+		return "", err
+	}
+	return progress.OperationId, nil
+}
@@ -0,0 +1,21 @@
+package internal
+
+type Apps struct {
+	CollectionSize int    `json:"collectionSize"`
+	Items          []App  `json:"items"`
+	LastPage       bool   `json:"lastPage"`
+	Offset         int    `json:"offset"`
+	Type           string `json:"type"`
+}
+
+type App struct {
+	CreatedAt int64     `json:"createdAt"`
+	CreatedBy string    `json:"createdBy"`
+	Desc      string    `json:"desc"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Ownership Ownership `json:"ownership"`
+	Status    string    `json:"status"`
+	UpdatedAt int64     `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy"`
+}
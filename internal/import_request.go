@@ -1,8 +1,11 @@
 package internal
 
 type ImportRequest struct {
-	ImportID                string `json:"importId"`
-	OwnershipGroupsMatching struct {
-		ImportID string `json:"importId"`
-	} `json:"ownershipGroupsMatching"`
+	ImportID                string                  `json:"importId"`
+	OwnershipGroupsMatching OwnershipGroupsMatching `json:"ownershipGroupsMatching"`
+}
+
+type OwnershipGroupsMatching struct {
+	ImportID string            `json:"importId"`
+	Matching map[string]string `json:"matching,omitempty"`
 }
@@ -0,0 +1,55 @@
+package internal
+
+import "encoding/json"
+
+type Model struct {
+	Type            string `json:"@type,omitempty"`
+	ID              string `json:"id"`
+	Desc            string `json:"desc"`
+	DestinationDesc string `json:"destinationDesc,omitempty"`
+	DestinationId   string `json:"destinationId,omitempty"`
+	CreatedAt       int64  `json:"createdAt,omitempty"`
+	CreatedBy       string `json:"createdBy,omitempty"`
+	UpdatedAt       int64  `json:"updatedAt,omitempty"`
+	UpdatedBy       string `json:"updatedBy,omitempty"`
+
+	// Raw holds every property of the model as received. The typed fields are written over it.
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+func (m *Model) UnmarshalJSON(data []byte) error {
+	type model Model
+	if err := json.Unmarshal(data, (*model)(m)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &m.Raw)
+}
+
+func (m Model) MarshalJSON() ([]byte, error) {
+	type model Model
+	data, err := json.Marshal(model(m))
+	if err != nil || len(m.Raw) == 0 {
+		return data, err
+	}
+
+	var typed map[string]json.RawMessage
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+	properties := make(map[string]json.RawMessage, len(m.Raw)+len(typed))
+	for name, value := range m.Raw {
+		properties[name] = value
+	}
+	for name, value := range typed {
+		properties[name] = value
+	}
+	return json.Marshal(properties)
+}
+
+type ModelsPage struct {
+	CollectionSize int     `json:"collectionSize"`
+	Items          []Model `json:"items"`
+	LastPage       bool    `json:"lastPage"`
+	Offset         int     `json:"offset"`
+	Type           string  `json:"type"`
+}
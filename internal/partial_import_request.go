@@ -2,19 +2,21 @@ package internal
 
 type PartialImportSchemasRequest struct {
 	Lists         []List         `json:"lists"`
-	Models        []interface{}  `json:"models"`
+	Models        []Model        `json:"models"`
 	Plans         []Plans        `json:"plans"`
 	RulesProjects []RulesProject `json:"rulesProjects"`
 }
 
 type List struct {
-	Desc         string `json:"desc"`
-	ExistingList bool   `json:"existingList"`
-	ID           string `json:"id"`
-	MatchingType string `json:"matchingType"`
-	TenancyScope string `json:"tenancyScope"`
-	Tokenized    bool   `json:"tokenized"`
-	Type         string `json:"type"`
+	Desc            string `json:"desc"`
+	DestinationDesc string `json:"destinationDesc,omitempty"`
+	DestinationId   string `json:"destinationId,omitempty"`
+	ExistingList    bool   `json:"existingList"`
+	ID              string `json:"id"`
+	MatchingType    string `json:"matchingType"`
+	TenancyScope    string `json:"tenancyScope"`
+	Tokenized       bool   `json:"tokenized"`
+	Type            string `json:"type"`
 }
 
 type Snapshot struct {
@@ -4,7 +4,21 @@ type PartialImportPrepareResponse struct {
 	ImportID      string         `json:"importId"`
 	ListItems     []interface{}  `json:"listItems"`
 	Lists         []List         `json:"lists"`
-	Models        []interface{}  `json:"models"`
+	Models        []Model        `json:"models"`
 	Plans         []Plans        `json:"plans"`
 	RulesProjects []RulesProject `json:"rulesProjects"`
 }
+
+type PartialImportSchemasResponse struct {
+	Errors []SchemaDiff `json:"errors"`
+}
+
+type SchemaDiff struct {
+	ActualType   string `json:"actualType"`
+	EntityDesc   string `json:"entityDesc"`
+	EntityId     string `json:"entityId"`
+	EntityType   string `json:"entityType"`
+	ExpectedType string `json:"expectedType"`
+	Field        string `json:"field"`
+	Message      string `json:"message"`
+}
@@ -0,0 +1,9 @@
+package internal
+
+type PlansPage struct {
+	CollectionSize int     `json:"collectionSize"`
+	Items          []Plans `json:"items"`
+	LastPage       bool    `json:"lastPage"`
+	Offset         int     `json:"offset"`
+	Type           string  `json:"type"`
+}
@@ -1,67 +1,38 @@
 package internal
 
+import "encoding/json"
+
 type PrepareImportResponse struct {
-	Errors          []interface{} `json:"errors"`
-	ImportID        string        `json:"importId"`
-	OwnershipGroups struct {
-		GroupsUsedInApp []interface{} `json:"groupsUsedInApp"`
-		SourceRootGroup struct {
-			Children []struct {
-				Children    []interface{} `json:"children"`
-				CreatedAt   int64         `json:"createdAt"`
-				CreatedBy   string        `json:"createdBy"`
-				ForTenant   bool          `json:"forTenant"`
-				HierarchyID string        `json:"hierarchyId"`
-				ID          string        `json:"id"`
-				Name        string        `json:"name"`
-				ParentID    string        `json:"parentId"`
-				Properties  struct {
-				} `json:"properties"`
-				Roles     []string `json:"roles"`
-				UpdatedAt int64    `json:"updatedAt"`
-				UpdatedBy string   `json:"updatedBy"`
-			} `json:"children"`
-			CreatedAt   int64  `json:"createdAt"`
-			CreatedBy   string `json:"createdBy"`
-			ForTenant   bool   `json:"forTenant"`
-			HierarchyID string `json:"hierarchyId"`
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			ParentID    string `json:"parentId"`
-			Properties  struct {
-			} `json:"properties"`
-			Roles     []interface{} `json:"roles"`
-			UpdatedAt int64         `json:"updatedAt"`
-			UpdatedBy string        `json:"updatedBy"`
-		} `json:"sourceRootGroup"`
-		TargetRootGroup struct {
-			Children []struct {
-				Children    []interface{} `json:"children"`
-				CreatedAt   int64         `json:"createdAt"`
-				CreatedBy   string        `json:"createdBy"`
-				ForTenant   bool          `json:"forTenant"`
-				HierarchyID string        `json:"hierarchyId"`
-				ID          string        `json:"id"`
-				Name        string        `json:"name"`
-				ParentID    string        `json:"parentId"`
-				Properties  struct {
-				} `json:"properties"`
-				Roles     []string `json:"roles"`
-				UpdatedAt int64    `json:"updatedAt"`
-				UpdatedBy string   `json:"updatedBy"`
-			} `json:"children"`
-			CreatedAt   int64  `json:"createdAt"`
-			CreatedBy   string `json:"createdBy"`
-			ForTenant   bool   `json:"forTenant"`
-			HierarchyID string `json:"hierarchyId"`
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			ParentID    string `json:"parentId"`
-			Properties  struct {
-			} `json:"properties"`
-			Roles     []interface{} `json:"roles"`
-			UpdatedAt int64         `json:"updatedAt"`
-			UpdatedBy string        `json:"updatedBy"`
-		} `json:"targetRootGroup"`
-	} `json:"ownershipGroups"`
+	Conflicts       []ImportConflict  `json:"conflicts"`
+	Errors          []json.RawMessage `json:"errors"`
+	ImportID        string            `json:"importId"`
+	OwnershipGroups OwnershipGroups   `json:"ownershipGroups"`
+}
+
+type ImportConflict struct {
+	Desc   string `json:"desc"`
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+	Type   string `json:"type"`
+}
+
+type OwnershipGroups struct {
+	GroupsUsedInApp []string       `json:"groupsUsedInApp"`
+	SourceRootGroup OwnershipGroup `json:"sourceRootGroup"`
+	TargetRootGroup OwnershipGroup `json:"targetRootGroup"`
+}
+
+type OwnershipGroup struct {
+	Children    []OwnershipGroup       `json:"children"`
+	CreatedAt   int64                  `json:"createdAt"`
+	CreatedBy   string                 `json:"createdBy"`
+	ForTenant   bool                   `json:"forTenant"`
+	HierarchyID string                 `json:"hierarchyId"`
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	ParentID    string                 `json:"parentId"`
+	Properties  map[string]interface{} `json:"properties"`
+	Roles       []string               `json:"roles"`
+	UpdatedAt   int64                  `json:"updatedAt"`
+	UpdatedBy   string                 `json:"updatedBy"`
 }
@@ -3,6 +3,7 @@ package internal
 type ProgressResponse struct {
 	HasFinished             bool      `json:"hasFinished"`
 	Members                 []Members `json:"members"`
+	OperationEndTimestamp   int64     `json:"operationEndTimestamp"`
 	OperationId             string    `json:"operationId"`
 	OperationStartTimestamp int64     `json:"operationStartTimestamp"`
 	OperationType           string    `json:"operationType"`
@@ -20,3 +21,10 @@ type Members struct {
 	Messages   []Messages `json:"messages"`
 	Status     string     `json:"status"`
 }
+
+type OperationHistory struct {
+	CollectionSize int                `json:"collectionSize"`
+	Items          []ProgressResponse `json:"items"`
+	LastPage       bool               `json:"lastPage"`
+	Offset         int                `json:"offset"`
+}
@@ -76,9 +76,9 @@ type Outcomes struct {
 type Outcomeconfig struct {
 	Outcomes []Outcomes `json:"outcomes"`
 }
-type Groups struct {
-	Jrmgtuwhfaznkb7Bvlzfjw int `json:"jrmgtUwhFazNKB7bVLZFJw"`
-}
+
+// Groups maps ownership group IDs to the access rights granted to them.
+type Groups map[string]int
 type Ownership struct {
 	CurrentUserRights int           `json:"currentUserRights"`
 	Groups            Groups        `json:"groups"`
@@ -0,0 +1,13 @@
+package internal
+
+type RulesProjectsPage struct {
+	CollectionSize int            `json:"collectionSize"`
+	Items          []RulesProject `json:"items"`
+	LastPage       bool           `json:"lastPage"`
+	Offset         int            `json:"offset"`
+	Type           string         `json:"type"`
+}
+
+type CreateSnapshotRequest struct {
+	Desc string `json:"desc"`
+}
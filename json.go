@@ -0,0 +1,65 @@
+package pulse
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// unmarshalExtra decodes data into v, a pointer to a struct without JSON methods, and returns
+// the properties of data that do not match a field of v.
+func unmarshalExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	for _, name := range jsonFields(reflect.TypeOf(v).Elem()) {
+		delete(properties, name)
+	}
+	if len(properties) == 0 {
+		return nil, nil
+	}
+	return properties, nil
+}
+
+// marshalExtra encodes v, a struct without JSON methods, adding the properties of extra
+// not already set by v.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := properties[name]; !ok {
+			properties[name] = value
+		}
+	}
+	return json.Marshal(properties)
+}
+
+// jsonFields returns the JSON property names of the fields of the struct type t.
+func jsonFields(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
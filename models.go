@@ -0,0 +1,194 @@
+package pulse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// Model is a machine learning model of the application.
+type Model struct {
+	ID        string
+	Desc      string
+	Type      string
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// ImportModelOptions configures ImportModel.
+type ImportModelOptions struct {
+	// Models maps source models to existing destination models. Keys are source model
+	// identifiers or names, values destination model identifiers or names. Unmapped models keep
+	// their identifier and name.
+	Models map[string]string
+}
+
+// ImportModel imports the models of the partial export zipFile, such as a retrained PMML model,
+// checks their schemas and publishes the application. The lists, plans and rules projects of the
+// export are skipped. It returns the identifiers of the destination models.
+func (c *Client) ImportModel(ctx context.Context, zipFile string, opts *ImportModelOptions) ([]string, error) {
+	var modelIDs []string
+	args := map[string]string{"zipFile": zipFile}
+	err := c.mutate(ctx, "ImportModel", args, func() error {
+		var err error
+		modelIDs, err = c.importModel(ctx, zipFile, opts)
+		return err
+	})
+	return modelIDs, err
+}
+
+func (c *Client) importModel(ctx context.Context, zipFile string, opts *ImportModelOptions) ([]string, error) {
+	if opts == nil {
+		opts = &ImportModelOptions{}
+	}
+
+	p, err := c.NewPartialImport(ctx, zipFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Models) == 0 {
+		return nil, errors.New("pulse: no model to import")
+	}
+	p.SkipAll()
+	for _, model := range p.Models {
+		model.Skip = false
+	}
+
+	if len(opts.Models) > 0 {
+		destinations, err := c.listModels(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, model := range p.Models {
+			ref, ok := opts.Models[model.ID]
+			if !ok {
+				ref, ok = opts.Models[model.Desc]
+			}
+			if !ok {
+				continue
+			}
+			destination, err := findModel(destinations, ref)
+			if err != nil {
+				return nil, err
+			}
+			model.MapTo(destination.ID, destination.Desc)
+		}
+	}
+
+	if err := p.commit(ctx); err != nil {
+		return nil, err
+	}
+
+	modelIDs := make([]string, 0, len(p.Models))
+	for _, model := range p.Models {
+		modelIDs = append(modelIDs, model.DestinationID)
+	}
+	return modelIDs, nil
+}
+
+// ListModels returns the machine learning models of the application.
+func (c *Client) ListModels(ctx context.Context) ([]Model, error) {
+	models, err := c.listModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Model, len(models))
+	for i, model := range models {
+		result[i] = Model{
+			ID:        model.ID,
+			Desc:      model.Desc,
+			Type:      model.Type,
+			CreatedBy: model.CreatedBy,
+			CreatedAt: fromMillis(model.CreatedAt),
+			UpdatedBy: model.UpdatedBy,
+			UpdatedAt: fromMillis(model.UpdatedAt),
+		}
+	}
+	return result, nil
+}
+
+// DeleteModel deletes the model identified by ref, an ID or a name.
+func (c *Client) DeleteModel(ctx context.Context, ref string) error {
+	return c.mutate(ctx, "DeleteModel", map[string]string{"model": ref}, func() error {
+		return c.deleteModel(ctx, ref)
+	})
+}
+
+func (c *Client) deleteModel(ctx context.Context, ref string) error {
+	models, err := c.listModels(ctx)
+	if err != nil {
+		return err
+	}
+	model, err := findModel(models, ref)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/models/%s", c.baseURL, c.appName, model.ID)
+	resp, err := c.delete(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("pulse: failed to delete model %s", model.Desc)
+	}
+	return nil
+}
+
+func findModel(models []internal.Model, ref string) (internal.Model, error) {
+	for _, model := range models {
+		if model.ID == ref {
+			return model, nil
+		}
+	}
+
+	var matches []internal.Model
+	for _, model := range models {
+		if model.Desc == ref {
+			matches = append(matches, model)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return internal.Model{}, fmt.Errorf("pulse: model %s not found", ref)
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, model := range matches {
+		ids[i] = model.ID
+	}
+	return internal.Model{}, fmt.Errorf("pulse: model name %s is ambiguous: %s", ref, strings.Join(ids, ", "))
+}
+
+func (c *Client) listModels(ctx context.Context) ([]internal.Model, error) {
+	models := make([]internal.Model, 0)
+	offset := 0
+
+	for {
+		url := fmt.Sprintf("%s/pulseviews/api/apps/%s/models/paged?limit=50&sort_by=desc&order=ASC&offset=%d&_=%d",
+			c.baseURL, c.appName, offset, time.Now().UnixNano()/int64(time.Millisecond))
+		var page internal.ModelsPage
+		if err := c.getJSON(ctx, url, &page); err != nil {
+			return nil, err
+		}
+
+		models = append(models, page.Items...)
+		offset += len(page.Items)
+		if page.LastPage || len(page.Items) == 0 || offset >= page.CollectionSize {
+			break
+		}
+	}
+
+	return models, nil
+}
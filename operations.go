@@ -0,0 +1,131 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// Operation is a lifecycle operation (start, update, stop...) executed on a Pulse application.
+type Operation struct {
+	ID       string
+	Type     string
+	User     string
+	Start    time.Time
+	End      time.Time
+	Finished bool
+	Rolling  bool
+	Status   string
+	Members  []OperationMember
+}
+
+// Operation statuses, as reported in the status field of an operation and of its members.
+const (
+	OperationRunning   = "RUNNING"
+	OperationSucceeded = "SUCCESS"
+	OperationFailed    = "FAILED"
+	OperationCancelled = "CANCELLED"
+)
+
+// Failed reports whether the operation, or one of its members, failed or was cancelled.
+func (o Operation) Failed() bool {
+	if failedStatus(o.Status) {
+		return true
+	}
+	for _, member := range o.Members {
+		if failedStatus(member.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+func failedStatus(status string) bool {
+	return strings.EqualFold(status, OperationFailed) || strings.EqualFold(status, OperationCancelled)
+}
+
+// OperationMember is the state of an operation on a single Pulse cluster member.
+type OperationMember struct {
+	ID       string
+	Desc     string
+	Status   string
+	Messages []OperationMessage
+}
+
+// OperationMessage is a task reported by a cluster member during an operation.
+type OperationMessage struct {
+	Task   string
+	Status string
+}
+
+// ListOperations returns the lifecycle operations of the application started at or after since,
+// oldest first, as recorded by Pulse in lifecycle/operations/paged. A zero since returns the
+// whole history.
+func (c *Client) ListOperations(ctx context.Context, since time.Time) ([]Operation, error) {
+	operations := make([]Operation, 0)
+	offset := 0
+
+	for {
+		url := fmt.Sprintf("%s/pulseviews/api/apps/%s/lifecycle/operations/paged?limit=50&offset=%d&since=%d&_=%d",
+			c.baseURL, c.appName, offset, toMillis(since), time.Now().UnixNano()/int64(time.Millisecond))
+		var history internal.OperationHistory
+		if err := c.getJSON(ctx, url, &history); err != nil {
+			return nil, err
+		}
+
+		for _, item := range history.Items {
+			operations = append(operations, newOperation(item))
+		}
+		offset += len(history.Items)
+		if history.LastPage || len(history.Items) == 0 || offset >= history.CollectionSize {
+			break
+		}
+	}
+
+	return operations, nil
+}
+
+func newOperation(progress internal.ProgressResponse) Operation {
+	members := make([]OperationMember, len(progress.Members))
+	for i, member := range progress.Members {
+		messages := make([]OperationMessage, len(member.Messages))
+		for j, message := range member.Messages {
+			messages[j] = OperationMessage{Task: message.Task, Status: message.Status}
+		}
+		members[i] = OperationMember{
+			ID:       member.MemberId,
+			Desc:     member.MemberDesc,
+			Status:   member.Status,
+			Messages: messages,
+		}
+	}
+
+	return Operation{
+		ID:       progress.OperationId,
+		Type:     progress.OperationType,
+		User:     progress.User,
+		Start:    fromMillis(progress.OperationStartTimestamp),
+		End:      fromMillis(progress.OperationEndTimestamp),
+		Finished: progress.HasFinished,
+		Rolling:  progress.Rolling,
+		Status:   progress.Status,
+		Members:  members,
+	}
+}
+
+func toMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
@@ -0,0 +1,59 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListOperations(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/lifecycle/operations/paged") {
+			return
+		}
+		queries = append(queries, r.URL.Query().Get("offset")+"/"+r.URL.Query().Get("since"))
+		if r.URL.Query().Get("offset") == "0" {
+			fmt.Fprint(w, `{"collectionSize": 2, "lastPage": false, "items": [
+				{"operationId": "o1", "operationType": "UPDATE", "user": "ana", "status": "SUCCESS", "operationStartTimestamp": 1000}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"collectionSize": 2, "lastPage": true, "items": [
+			{"operationId": "o2", "operationType": "START", "status": "FAILED", "hasFinished": true}]}`)
+	}))
+	defer server.Close()
+
+	client, err := New(&Options{BaseURL: server.URL, AppName: "app", DisableAudit: true, DisableSnapshots: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operations, err := client.ListOperations(context.Background(), time.Unix(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(queries, " "), "0/1000 1/1000"; got != want {
+		t.Errorf("got pages %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"count", len(operations), 2},
+		{"id", operations[0].ID, "o1"},
+		{"user", operations[0].User, "ana"},
+		{"start", operations[0].Start, time.Unix(1, 0)},
+		{"failed", operations[1].Failed(), true},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
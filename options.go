@@ -1,6 +1,10 @@
 package pulse
 
-import "time"
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
 
 // Options stores the required parameters to be used by the client for authenticating with Feedzai Pulse API.
 type Options struct {
@@ -15,4 +19,42 @@ type Options struct {
 	AppName string
 	// Timeout specifies a time limit for requests made by the client.
 	Timeout time.Duration
+	// AuditSink receives a record of every mutating call made by the client.
+	AuditSink AuditSink
+	// AuditFile is the name of the JSON lines file used as audit sink when AuditSink is nil.
+	// It defaults to audit.jsonl in the go-pulse directory of the user cache directory.
+	AuditFile string
+	// DisableAudit turns off the default audit file when AuditSink is nil.
+	DisableAudit bool
+	// Guard selects what happens when a mutating call is made while a lifecycle operation
+	// is running on the application. The zero value disables the check.
+	Guard GuardPolicy
+	// GuardTimeout limits how long GuardWait and GuardAbort wait for the running operation
+	// to finish. Zero means no limit.
+	GuardTimeout time.Duration
+	// PollInterval is the delay between two checks of the current lifecycle operation.
+	// It defaults to 5 seconds.
+	PollInterval time.Duration
+	// SnapshotStore receives the state of a workflow before the client modifies it, for use
+	// by RollbackWorkflow.
+	SnapshotStore SnapshotStore
+	// SnapshotDir is the directory used as snapshot store when SnapshotStore is nil. It
+	// defaults to the snapshots directory in the go-pulse directory of the user cache directory.
+	SnapshotDir string
+	// DisableSnapshots turns off the default snapshot directory when SnapshotStore is nil.
+	DisableSnapshots bool
+}
+
+// defaultPath returns the path of name in the go-pulse directory of the user cache directory,
+// or of the temporary directory when the user has none, creating the directory if needed.
+func defaultPath(name string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	dir = filepath.Join(dir, "go-pulse")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
 }
@@ -0,0 +1,141 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Outcome returns the outcome identified by ref, an ID or a label, or nil.
+func (c *OutcomeConfig) Outcome(ref string) *Outcome {
+	for i := range c.Outcomes {
+		if c.Outcomes[i].ID == ref {
+			return &c.Outcomes[i]
+		}
+	}
+	for i := range c.Outcomes {
+		if c.Outcomes[i].Label == ref {
+			return &c.Outcomes[i]
+		}
+	}
+	return nil
+}
+
+// AddOutcome appends outcome, whose ID must not be used by another outcome.
+func (c *OutcomeConfig) AddOutcome(outcome Outcome) error {
+	if outcome.ID == "" {
+		return errors.New("pulse: outcome without id")
+	}
+	for _, o := range c.Outcomes {
+		if o.ID == outcome.ID {
+			return fmt.Errorf("pulse: outcome %s already exists", outcome.ID)
+		}
+	}
+	if err := outcome.checkDefault(outcome.DefaultValue); err != nil {
+		return err
+	}
+	c.Outcomes = append(c.Outcomes, outcome)
+	return nil
+}
+
+// RemoveOutcome removes the outcome with the given ID.
+func (c *OutcomeConfig) RemoveOutcome(id string) error {
+	for i, o := range c.Outcomes {
+		if o.ID == id {
+			c.Outcomes = append(c.Outcomes[:i], c.Outcomes[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("pulse: outcome %s not found", id)
+}
+
+// AddCategoricalValue adds value to the values an outcome can take.
+func (o *Outcome) AddCategoricalValue(value string) error {
+	for _, v := range o.CategoricalValues {
+		if v == value {
+			return fmt.Errorf("pulse: outcome %s already has value %q", o.ID, value)
+		}
+	}
+	o.CategoricalValues = append(o.CategoricalValues, value)
+	return nil
+}
+
+// SetDefault changes the default value of an outcome. The default value of a categorical outcome
+// must be one of its values.
+func (o *Outcome) SetDefault(value interface{}) error {
+	if err := o.checkDefault(value); err != nil {
+		return err
+	}
+	o.DefaultValue = value
+	return nil
+}
+
+func (o *Outcome) checkDefault(value interface{}) error {
+	if len(o.CategoricalValues) == 0 || value == nil {
+		return nil
+	}
+	for _, v := range o.CategoricalValues {
+		if v == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("pulse: default value %v of outcome %s is not one of %s",
+		value, o.ID, strings.Join(o.CategoricalValues, ", "))
+}
+
+// ValidateOutcomes checks that outcome IDs are unique, that categorical defaults are among the
+// outcome values and that the outcomes referenced by the workflow elements exist.
+func (w *Workflow) ValidateOutcomes() error {
+	var problems []string
+
+	ids := make(map[string]bool)
+	for i := range w.OutcomeConfig.Outcomes {
+		o := &w.OutcomeConfig.Outcomes[i]
+		if ids[o.ID] {
+			problems = append(problems, fmt.Sprintf("duplicate outcome %s", o.ID))
+		}
+		ids[o.ID] = true
+		if err := o.checkDefault(o.DefaultValue); err != nil {
+			problems = append(problems, strings.TrimPrefix(err.Error(), "pulse: "))
+		}
+	}
+
+	for _, e := range w.Config.Elements {
+		for _, id := range e.OutcomeIDs {
+			if !ids[id] {
+				problems = append(problems, fmt.Sprintf("element %s references unknown outcome %s", e.Desc, id))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("pulse: invalid outcomes: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// outcomeState returns the outcomes of w and the outcomes its elements reference, to tell
+// whether an update changed them. A marshaling error is reported when the workflow is saved.
+func outcomeState(w *Workflow) string {
+	references := make([][]string, len(w.Config.Elements))
+	for i, e := range w.Config.Elements {
+		references[i] = append([]string{e.ID}, e.OutcomeIDs...)
+	}
+	state, _ := json.Marshal(struct {
+		Outcomes   OutcomeConfig
+		References [][]string
+	}{w.OutcomeConfig, references})
+	return string(state)
+}
+
+// UpdateOutcomes lets mutate change the outcomes of the workflow identified by name, an ID or a
+// name, and saves them through UpdateWorkflow.
+func (c *Client) UpdateOutcomes(ctx context.Context, name string, mutate func(*OutcomeConfig) error, opts *UpdateWorkflowOptions) error {
+	return c.UpdateWorkflow(ctx, name, func(w *Workflow) error {
+		return mutate(&w.OutcomeConfig)
+	}, opts)
+}
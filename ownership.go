@@ -0,0 +1,153 @@
+package pulse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// OwnershipGroup is a node of a Pulse ownership group hierarchy.
+type OwnershipGroup struct {
+	ID          string
+	Name        string
+	ParentID    string
+	HierarchyID string
+	Roles       []string
+	Children    []OwnershipGroup
+}
+
+// OwnershipGroups holds the ownership group hierarchies reported when preparing an app import.
+type OwnershipGroups struct {
+	// UsedInApp holds the identifiers of the source groups referenced by the imported app.
+	UsedInApp []string
+	// Source is the root group of the environment the app was exported from.
+	Source OwnershipGroup
+	// Target is the root group of the environment the app is imported into.
+	Target OwnershipGroup
+}
+
+// ImportAppOptions configures how an app export is imported.
+type ImportAppOptions struct {
+	// GroupMapping maps source ownership groups to target groups. Keys and values are
+	// group identifiers or names. Source groups missing from the mapping are matched
+	// with the target group having the same path, or else the same unique name.
+	GroupMapping map[string]string
+	// IgnoreErrors commits the import even if Pulse reported errors while preparing it.
+	IgnoreErrors bool
+}
+
+func newOwnershipGroups(groups internal.OwnershipGroups) *OwnershipGroups {
+	return &OwnershipGroups{
+		UsedInApp: groups.GroupsUsedInApp,
+		Source:    newOwnershipGroup(groups.SourceRootGroup),
+		Target:    newOwnershipGroup(groups.TargetRootGroup),
+	}
+}
+
+func newOwnershipGroup(group internal.OwnershipGroup) OwnershipGroup {
+	children := make([]OwnershipGroup, len(group.Children))
+	for i, child := range group.Children {
+		children[i] = newOwnershipGroup(child)
+	}
+	return OwnershipGroup{
+		ID:          group.ID,
+		Name:        group.Name,
+		ParentID:    group.ParentID,
+		HierarchyID: group.HierarchyID,
+		Roles:       group.Roles,
+		Children:    children,
+	}
+}
+
+// Walk calls fn for g and each of its descendants, depth first. path holds the names of the
+// groups from g, excluded, down to the visited group, separated by slashes; it is empty for g.
+func (g *OwnershipGroup) Walk(fn func(group *OwnershipGroup, path string)) {
+	fn(g, "")
+	for i := range g.Children {
+		g.Children[i].walk("", fn)
+	}
+}
+
+func (g *OwnershipGroup) walk(parent string, fn func(group *OwnershipGroup, path string)) {
+	path := g.Name
+	if parent != "" {
+		path = parent + "/" + g.Name
+	}
+	fn(g, path)
+	for i := range g.Children {
+		g.Children[i].walk(path, fn)
+	}
+}
+
+// MatchOwnershipGroups returns the target group identifier of every source group used by the
+// imported app, or of every source group when the app does not report the groups it uses.
+// Keys and values of mapping are group identifiers or names; unmapped groups are matched by
+// their path below the root group, then by unique name. The source root group is matched with
+// the target root group unless mapped otherwise.
+// An error lists the source groups that could not be matched.
+func MatchOwnershipGroups(groups *OwnershipGroups, mapping map[string]string) (map[string]string, error) {
+	targetsByID := make(map[string]string)
+	targetsByPath := make(map[string]string)
+	targetsByName := make(map[string][]string)
+	groups.Target.Walk(func(group *OwnershipGroup, path string) {
+		targetsByID[group.ID] = group.ID
+		targetsByPath[path] = group.ID
+		targetsByName[group.Name] = append(targetsByName[group.Name], group.ID)
+	})
+
+	resolveTarget := func(ref string) (string, error) {
+		if id, ok := targetsByID[ref]; ok {
+			return id, nil
+		}
+		switch ids := targetsByName[ref]; len(ids) {
+		case 0:
+			return "", fmt.Errorf("pulse: target ownership group %s not found", ref)
+		case 1:
+			return ids[0], nil
+		default:
+			return "", fmt.Errorf("pulse: target ownership group name %s is ambiguous", ref)
+		}
+	}
+
+	used := make(map[string]bool, len(groups.UsedInApp))
+	for _, id := range groups.UsedInApp {
+		used[id] = true
+	}
+
+	matching := make(map[string]string)
+	var unmapped []string
+	var err error
+	groups.Source.Walk(func(group *OwnershipGroup, path string) {
+		if err != nil || (len(used) > 0 && !used[group.ID] && path != "") {
+			return
+		}
+
+		ref, ok := mapping[group.ID]
+		if !ok {
+			ref, ok = mapping[group.Name]
+		}
+		if ok {
+			matching[group.ID], err = resolveTarget(ref)
+			return
+		}
+
+		if id, ok := targetsByPath[path]; ok {
+			matching[group.ID] = id
+		} else if ids := targetsByName[group.Name]; len(ids) == 1 {
+			matching[group.ID] = ids[0]
+		} else {
+			unmapped = append(unmapped, path)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unmapped) > 0 {
+		sort.Strings(unmapped)
+		return nil, fmt.Errorf("pulse: unmapped ownership groups: %s", strings.Join(unmapped, ", "))
+	}
+	return matching, nil
+}
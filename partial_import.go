@@ -0,0 +1,550 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// PartialImport is a partial export uploaded to Pulse and waiting to be committed. Every entity
+// of the export is imported under its source identifier and name unless it is skipped or
+// remapped before calling Commit.
+type PartialImport struct {
+	c *Client
+
+	ImportID      string
+	Lists         []*PartialImportList
+	Models        []*PartialImportModel
+	Plans         []*PartialImportPlan
+	RulesProjects []*PartialImportRulesProject
+}
+
+// PartialImportList is a managed list of a partial import.
+type PartialImportList struct {
+	ID              string
+	Desc            string
+	Type            string
+	MatchingType    string
+	TenancyScope    string
+	Tokenized       bool
+	Skip            bool
+	DestinationID   string
+	DestinationDesc string
+	// ExistingList reuses the destination list instead of creating it.
+	ExistingList bool
+}
+
+// PartialImportModel is a machine learning model of a partial import.
+type PartialImportModel struct {
+	ID              string
+	Desc            string
+	Type            string
+	Skip            bool
+	DestinationID   string
+	DestinationDesc string
+
+	raw map[string]json.RawMessage
+}
+
+// PartialImportPlan is an execution plan of a partial import.
+type PartialImportPlan struct {
+	ID              string
+	Desc            string
+	Skip            bool
+	DestinationID   string
+	DestinationDesc string
+	Executions      []*PartialImportExecution
+}
+
+// PartialImportExecution is an execution of a plan of a partial import.
+type PartialImportExecution struct {
+	ID   string
+	Desc string
+	Skip bool
+}
+
+// PartialImportRulesProject is a rules project of a partial import.
+type PartialImportRulesProject struct {
+	ID              string
+	Desc            string
+	Type            string
+	Skip            bool
+	DestinationID   string
+	DestinationDesc string
+	Snapshots       []*PartialImportSnapshot
+}
+
+// PartialImportSnapshot is a snapshot of a rules project of a partial import.
+type PartialImportSnapshot struct {
+	ID               string
+	Desc             string
+	Skip             bool
+	WorkflowMappings []WorkflowMapping
+}
+
+// WorkflowMapping deploys a rules snapshot to a workflow element.
+type WorkflowMapping struct {
+	WorkflowID string
+	ElementID  string
+}
+
+// MapTo imports the list as the destination list id named desc.
+func (l *PartialImportList) MapTo(id, desc string) {
+	l.DestinationID = id
+	l.DestinationDesc = desc
+}
+
+// UseExisting imports the list into the existing destination list id.
+func (l *PartialImportList) UseExisting(id string) {
+	l.DestinationID = id
+	l.ExistingList = true
+}
+
+// MapTo imports the model as the destination model id named desc.
+func (m *PartialImportModel) MapTo(id, desc string) {
+	m.DestinationID = id
+	m.DestinationDesc = desc
+}
+
+// MapTo imports the plan into the destination plan id named desc.
+func (p *PartialImportPlan) MapTo(id, desc string) {
+	p.DestinationID = id
+	p.DestinationDesc = desc
+}
+
+// MapTo imports the rules project into the destination project id named desc.
+func (r *PartialImportRulesProject) MapTo(id, desc string) {
+	r.DestinationID = id
+	r.DestinationDesc = desc
+}
+
+// Execution returns the execution identified by ref, an ID or a name, or nil.
+func (p *PartialImportPlan) Execution(ref string) *PartialImportExecution {
+	for _, exec := range p.Executions {
+		if exec.ID == ref || exec.Desc == ref {
+			return exec
+		}
+	}
+	return nil
+}
+
+// Snapshot returns the snapshot identified by ref, an ID or a name, or nil.
+func (r *PartialImportRulesProject) Snapshot(ref string) *PartialImportSnapshot {
+	for _, snapshot := range r.Snapshots {
+		if snapshot.ID == ref || snapshot.Desc == ref {
+			return snapshot
+		}
+	}
+	return nil
+}
+
+// List returns the list identified by ref, an ID or a name, or nil.
+func (p *PartialImport) List(ref string) *PartialImportList {
+	for _, list := range p.Lists {
+		if list.ID == ref || list.Desc == ref {
+			return list
+		}
+	}
+	return nil
+}
+
+// Model returns the model identified by ref, an ID or a name, or nil.
+func (p *PartialImport) Model(ref string) *PartialImportModel {
+	for _, model := range p.Models {
+		if model.ID == ref || model.Desc == ref {
+			return model
+		}
+	}
+	return nil
+}
+
+// Plan returns the plan identified by ref, an ID or a name, or nil.
+func (p *PartialImport) Plan(ref string) *PartialImportPlan {
+	for _, plan := range p.Plans {
+		if plan.ID == ref || plan.Desc == ref {
+			return plan
+		}
+	}
+	return nil
+}
+
+// RulesProject returns the rules project identified by ref, an ID or a name, or nil.
+func (p *PartialImport) RulesProject(ref string) *PartialImportRulesProject {
+	for _, project := range p.RulesProjects {
+		if project.ID == ref || project.Desc == ref {
+			return project
+		}
+	}
+	return nil
+}
+
+// SkipAll skips every entity of the import. Callers then unskip the entities they want.
+func (p *PartialImport) SkipAll() {
+	for _, list := range p.Lists {
+		list.Skip = true
+	}
+	for _, model := range p.Models {
+		model.Skip = true
+	}
+	for _, plan := range p.Plans {
+		plan.Skip = true
+	}
+	for _, project := range p.RulesProjects {
+		project.Skip = true
+	}
+}
+
+// NewPartialImport uploads the partial export zipFile to Pulse and returns the import to configure.
+func (c *Client) NewPartialImport(ctx context.Context, zipFile string) (*PartialImport, error) {
+	resp, err := c.partialImportPrepare(ctx, zipFile)
+	if err != nil {
+		return nil, err
+	}
+	return newPartialImport(c, resp), nil
+}
+
+func newPartialImport(c *Client, resp *internal.PartialImportPrepareResponse) *PartialImport {
+	p := &PartialImport{c: c, ImportID: resp.ImportID}
+
+	for _, list := range resp.Lists {
+		p.Lists = append(p.Lists, &PartialImportList{
+			ID:              list.ID,
+			Desc:            list.Desc,
+			Type:            list.Type,
+			MatchingType:    list.MatchingType,
+			TenancyScope:    list.TenancyScope,
+			Tokenized:       list.Tokenized,
+			DestinationID:   list.ID,
+			DestinationDesc: list.Desc,
+		})
+	}
+
+	for _, model := range resp.Models {
+		p.Models = append(p.Models, &PartialImportModel{
+			ID:              model.ID,
+			Desc:            model.Desc,
+			Type:            model.Type,
+			DestinationID:   model.ID,
+			DestinationDesc: model.Desc,
+			raw:             model.Raw,
+		})
+	}
+
+	for _, plan := range resp.Plans {
+		execs := make([]*PartialImportExecution, len(plan.Executions))
+		for i, exec := range plan.Executions {
+			execs[i] = &PartialImportExecution{ID: exec.ID, Desc: exec.Desc}
+		}
+		p.Plans = append(p.Plans, &PartialImportPlan{
+			ID:              plan.ID,
+			Desc:            plan.Desc,
+			DestinationID:   plan.ID,
+			DestinationDesc: plan.Desc,
+			Executions:      execs,
+		})
+	}
+
+	for _, project := range resp.RulesProjects {
+		snapshots := make([]*PartialImportSnapshot, len(project.Snapshots))
+		for i, snapshot := range project.Snapshots {
+			snapshots[i] = &PartialImportSnapshot{ID: snapshot.ID, Desc: snapshot.Desc}
+		}
+		p.RulesProjects = append(p.RulesProjects, &PartialImportRulesProject{
+			ID:              project.ID,
+			Desc:            project.Desc,
+			Type:            project.Type,
+			DestinationID:   project.ID,
+			DestinationDesc: project.Desc,
+			Snapshots:       snapshots,
+		})
+	}
+
+	return p
+}
+
+func (p *PartialImport) request() *internal.PartialImportSchemasRequest {
+	req := &internal.PartialImportSchemasRequest{
+		Lists:         []internal.List{},
+		Models:        []internal.Model{},
+		Plans:         []internal.Plans{},
+		RulesProjects: []internal.RulesProject{},
+	}
+
+	for _, list := range p.Lists {
+		if list.Skip {
+			continue
+		}
+		req.Lists = append(req.Lists, internal.List{
+			Desc:            list.Desc,
+			DestinationDesc: list.DestinationDesc,
+			DestinationId:   list.DestinationID,
+			ExistingList:    list.ExistingList,
+			ID:              list.ID,
+			MatchingType:    list.MatchingType,
+			TenancyScope:    list.TenancyScope,
+			Tokenized:       list.Tokenized,
+			Type:            list.Type,
+		})
+	}
+
+	for _, model := range p.Models {
+		if model.Skip {
+			continue
+		}
+		req.Models = append(req.Models, internal.Model{
+			Type:            model.Type,
+			ID:              model.ID,
+			Desc:            model.Desc,
+			DestinationDesc: model.DestinationDesc,
+			DestinationId:   model.DestinationID,
+			Raw:             model.raw,
+		})
+	}
+
+	for _, plan := range p.Plans {
+		if plan.Skip {
+			continue
+		}
+		execs := make([]internal.Executions, 0, len(plan.Executions))
+		for _, exec := range plan.Executions {
+			if !exec.Skip {
+				execs = append(execs, internal.Executions{ID: exec.ID})
+			}
+		}
+		req.Plans = append(req.Plans, internal.Plans{
+			Executions:      execs,
+			ID:              plan.ID,
+			DestinationId:   plan.DestinationID,
+			DestinationDesc: plan.DestinationDesc,
+		})
+	}
+
+	for _, project := range p.RulesProjects {
+		if project.Skip {
+			continue
+		}
+		snapshots := make([]internal.Snapshot, 0, len(project.Snapshots))
+		for _, snapshot := range project.Snapshots {
+			if snapshot.Skip {
+				continue
+			}
+			mappings := make([]internal.WorkflowMapping, len(snapshot.WorkflowMappings))
+			for i, mapping := range snapshot.WorkflowMappings {
+				mappings[i] = internal.WorkflowMapping{
+					WorkflowElementId: mapping.ElementID,
+					WorkflowId:        mapping.WorkflowID,
+				}
+			}
+			snapshots = append(snapshots, internal.Snapshot{
+				Desc:             snapshot.Desc,
+				ID:               snapshot.ID,
+				WorkflowMappings: mappings,
+			})
+		}
+		req.RulesProjects = append(req.RulesProjects, internal.RulesProject{
+			ID:              project.ID,
+			DestinationDesc: project.DestinationDesc,
+			DestinationId:   project.DestinationID,
+			Snapshots:       snapshots,
+			Type:            project.Type,
+		})
+	}
+
+	return req
+}
+
+// Commit validates the schemas of the configured entities, imports them and publishes the
+// application.
+func (p *PartialImport) Commit(ctx context.Context) error {
+	args := map[string]string{"importID": p.ImportID}
+	return p.c.mutate(ctx, "PartialImport", args, func() error {
+		return p.commit(ctx)
+	})
+}
+
+func (p *PartialImport) commit(ctx context.Context) error {
+	c := p.c
+	schemaPayload, err := json.Marshal(p.request())
+	if err != nil {
+		return err
+	}
+
+	findings, err := p.checkSchemas(ctx, schemaPayload)
+	if err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return &SchemaError{Findings: findings}
+	}
+
+	commitURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportCommit/%s",
+		c.baseURL, c.appName, p.ImportID)
+	commitResp, err := c.post(ctx, commitURL, schemaPayload)
+	if err != nil {
+		return err
+	}
+	defer commitResp.Body.Close()
+
+	if commitResp.StatusCode != http.StatusNoContent {
+		return errors.New("pulse: failed partial commit")
+	}
+
+	return c.update(ctx)
+}
+
+// SchemaFinding is a mismatch between the schema of an imported entity and the schema
+// of the destination application.
+type SchemaFinding struct {
+	// Entity is the type of the entity, such as a rules project or a model.
+	Entity     string
+	EntityID   string
+	EntityDesc string
+	Field      string
+	Expected   string
+	Actual     string
+	Message    string
+}
+
+func (f SchemaFinding) String() string {
+	name := f.EntityDesc
+	if name == "" {
+		name = f.EntityID
+	}
+
+	parts := make([]string, 0, 3)
+	if subject := strings.TrimSpace(f.Entity + " " + name); subject != "" {
+		parts = append(parts, subject)
+	}
+	if f.Field != "" {
+		parts = append(parts, fmt.Sprintf("field %s expected %s, got %s", f.Field, f.Expected, f.Actual))
+	}
+	if f.Message != "" {
+		parts = append(parts, f.Message)
+	}
+	return strings.Join(parts, ": ")
+}
+
+// SchemaError is returned when Pulse rejects the schemas of a partial import.
+type SchemaError struct {
+	Findings []SchemaFinding
+}
+
+func (e *SchemaError) Error() string {
+	findings := make([]string, len(e.Findings))
+	for i, finding := range e.Findings {
+		findings[i] = finding.String()
+	}
+	return "pulse: failed schema validation: " + strings.Join(findings, "; ")
+}
+
+// Check validates the schemas of the configured entities without committing the import.
+// It returns the mismatches reported by Pulse, if any.
+func (p *PartialImport) Check(ctx context.Context) ([]SchemaFinding, error) {
+	schemaPayload, err := json.Marshal(p.request())
+	if err != nil {
+		return nil, err
+	}
+	return p.checkSchemas(ctx, schemaPayload)
+}
+
+// checkSchemas returns the schema findings Pulse reports, with a 400 or 422 response, for the
+// entities of schemaPayload. Other failed responses are returned as errors.
+func (p *PartialImport) checkSchemas(ctx context.Context, schemaPayload []byte) ([]SchemaFinding, error) {
+	c := p.c
+	checkSchemaURL := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportCheckSchemas/%s",
+		c.baseURL, c.appName, p.ImportID)
+	checkSchemaResp, err := c.post(ctx, checkSchemaURL, schemaPayload)
+	if err != nil {
+		return nil, err
+	}
+	defer checkSchemaResp.Body.Close()
+
+	if checkSchemaResp.StatusCode == http.StatusOK {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(checkSchemaResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch checkSchemaResp.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return newSchemaFindings(body), nil
+	}
+	return nil, fmt.Errorf("pulse: schema check failed with status %d: %s", checkSchemaResp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// newSchemaFindings decodes the schema differences reported by Pulse. A body that cannot
+// be decoded is reported as a single finding.
+func newSchemaFindings(body []byte) []SchemaFinding {
+	var resp internal.PartialImportSchemasResponse
+	if err := json.Unmarshal(body, &resp); err != nil || len(resp.Errors) == 0 {
+		if err := json.Unmarshal(body, &resp.Errors); err != nil || len(resp.Errors) == 0 {
+			message := strings.TrimSpace(string(body))
+			if message == "" {
+				message = "schema check rejected"
+			}
+			return []SchemaFinding{{Message: message}}
+		}
+	}
+
+	findings := make([]SchemaFinding, len(resp.Errors))
+	for i, diff := range resp.Errors {
+		findings[i] = SchemaFinding{
+			Entity:     diff.EntityType,
+			EntityID:   diff.EntityId,
+			EntityDesc: diff.EntityDesc,
+			Field:      diff.Field,
+			Expected:   diff.ExpectedType,
+			Actual:     diff.ActualType,
+			Message:    diff.Message,
+		}
+	}
+	return findings
+}
+
+// Cancel discards the import on the server.
+func (p *PartialImport) Cancel(ctx context.Context) error {
+	c := p.c
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/partialImportCancel/%s",
+		c.baseURL, c.appName, p.ImportID)
+	resp, err := c.post(ctx, url, []byte{})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return errors.New("pulse: failed cancelling partial import")
+}
+
+// CheckPartialImport uploads the partial export zipFile, lets mapping configure the import
+// and checks its schemas without committing it. The import is cancelled in every case.
+// mapping may be nil to check every entity under its source identifier.
+func (c *Client) CheckPartialImport(ctx context.Context, zipFile string, mapping func(*PartialImport) error) (findings []SchemaFinding, err error) {
+	p, err := c.NewPartialImport(ctx, zipFile)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cancelErr := p.Cancel(context.Background()); cancelErr != nil && err == nil {
+			err = cancelErr
+		}
+	}()
+
+	if mapping != nil {
+		if err := mapping(p); err != nil {
+			return nil, err
+		}
+	}
+	return p.Check(ctx)
+}
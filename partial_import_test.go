@@ -0,0 +1,66 @@
+package pulse
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+func TestPartialImportModelPassthrough(t *testing.T) {
+	const prepared = `{"importId": "i1", "models": [
+		{"@type": "pmml", "id": "m1", "desc": "Score", "pmmlVersion": "4.3", "inputs": [{"name": "amount"}]},
+		{"id": "m2", "desc": "Skipped"}]}`
+
+	var resp internal.PartialImportPrepareResponse
+	if err := json.Unmarshal([]byte(prepared), &resp); err != nil {
+		t.Fatal(err)
+	}
+	p := newPartialImport(nil, &resp)
+
+	tests := []struct {
+		name   string
+		mutate func(*PartialImport)
+		want   map[string]interface{}
+	}{
+		{
+			name:   "unchanged",
+			mutate: func(p *PartialImport) {},
+			want: map[string]interface{}{
+				"@type": "pmml", "id": "m1", "desc": "Score", "pmmlVersion": "4.3",
+				"inputs":        []interface{}{map[string]interface{}{"name": "amount"}},
+				"destinationId": "m1", "destinationDesc": "Score",
+			},
+		},
+		{
+			name:   "mapped",
+			mutate: func(p *PartialImport) { p.Model("m1").MapTo("m9", "Score v9") },
+			want: map[string]interface{}{
+				"@type": "pmml", "id": "m1", "desc": "Score", "pmmlVersion": "4.3",
+				"inputs":        []interface{}{map[string]interface{}{"name": "amount"}},
+				"destinationId": "m9", "destinationDesc": "Score v9",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p.Model("m2").Skip = true
+			test.mutate(p)
+
+			data, err := json.Marshal(p.request())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var req struct {
+				Models []map[string]interface{} `json:"models"`
+			}
+			if err := json.Unmarshal(data, &req); err != nil {
+				t.Fatal(err)
+			}
+			if len(req.Models) != 1 || !reflect.DeepEqual(req.Models[0], test.want) {
+				t.Errorf("got models %v, want [%v]", req.Models, test.want)
+			}
+		})
+	}
+}
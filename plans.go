@@ -0,0 +1,204 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// ImportPlanOptions configures ImportPlan.
+type ImportPlanOptions struct {
+	// Plans maps source plans to existing destination plans. Keys are source plan identifiers or
+	// names, values destination plan identifiers or names. Unmapped plans keep their identifier
+	// and name.
+	Plans map[string]string
+	// Executions selects the executions, by ID or name, imported for a source plan identified
+	// by ID or name. Plans missing from the map are imported with all their executions.
+	Executions map[string][]string
+}
+
+// ImportPlan imports the execution plans of the partial export zipFile and publishes the
+// application. It returns the identifiers of the destination plans.
+func (c *Client) ImportPlan(ctx context.Context, zipFile string, opts *ImportPlanOptions) ([]string, error) {
+	var planIDs []string
+	args := map[string]string{"zipFile": zipFile}
+	err := c.mutate(ctx, "ImportPlan", args, func() error {
+		var err error
+		planIDs, err = c.importPlan(ctx, zipFile, opts)
+		return err
+	})
+	return planIDs, err
+}
+
+func (c *Client) importPlan(ctx context.Context, zipFile string, opts *ImportPlanOptions) ([]string, error) {
+	if opts == nil {
+		opts = &ImportPlanOptions{}
+	}
+
+	p, err := c.NewPartialImport(ctx, zipFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range p.Lists {
+		list.Skip = true
+	}
+
+	if err := c.mapPlans(ctx, p.Plans, opts); err != nil {
+		return nil, err
+	}
+
+	if err := p.commit(ctx); err != nil {
+		return nil, err
+	}
+
+	planIDs := make([]string, 0, len(p.Plans))
+	for _, plan := range p.Plans {
+		planIDs = append(planIDs, plan.DestinationID)
+	}
+	return planIDs, nil
+}
+
+func (c *Client) mapPlans(ctx context.Context, plans []*PartialImportPlan, opts *ImportPlanOptions) error {
+	if err := unknownPlans(plans, "Plans", opts.Plans); err != nil {
+		return err
+	}
+	executions := make(map[string]string, len(opts.Executions))
+	for ref := range opts.Executions {
+		executions[ref] = ""
+	}
+	if err := unknownPlans(plans, "Executions", executions); err != nil {
+		return err
+	}
+
+	var destinations []internal.Plans
+	if len(opts.Plans) > 0 {
+		var err error
+		if destinations, err = c.listPlans(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, plan := range plans {
+		if err := selectExecutions(plan, opts.Executions); err != nil {
+			return err
+		}
+
+		ref, ok := opts.Plans[plan.ID]
+		if !ok {
+			ref, ok = opts.Plans[plan.Desc]
+		}
+		if !ok {
+			continue
+		}
+
+		destination, err := findPlan(destinations, ref)
+		if err != nil {
+			return err
+		}
+		plan.MapTo(destination.ID, destination.Desc)
+	}
+	return nil
+}
+
+// unknownPlans returns an error naming the keys of refs, taken from the option field, that
+// identify none of plans.
+func unknownPlans(plans []*PartialImportPlan, field string, refs map[string]string) error {
+	var unknown []string
+	for ref := range refs {
+		found := false
+		for _, plan := range plans {
+			if plan.ID == ref || plan.Desc == ref {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, ref)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("pulse: unknown plans in ImportPlanOptions.%s: %s", field, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func selectExecutions(plan *PartialImportPlan, selection map[string][]string) error {
+	refs, ok := selection[plan.ID]
+	if !ok {
+		refs, ok = selection[plan.Desc]
+	}
+	if !ok {
+		return nil
+	}
+
+	for _, exec := range plan.Executions {
+		exec.Skip = true
+	}
+	var unknown []string
+	for _, ref := range refs {
+		exec := plan.Execution(ref)
+		if exec == nil {
+			unknown = append(unknown, ref)
+			continue
+		}
+		exec.Skip = false
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("pulse: executions not found in plan %s: %s", plan.Desc, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func findPlan(plans []internal.Plans, ref string) (internal.Plans, error) {
+	for _, plan := range plans {
+		if plan.ID == ref {
+			return plan, nil
+		}
+	}
+
+	var matches []internal.Plans
+	for _, plan := range plans {
+		if plan.Desc == ref {
+			matches = append(matches, plan)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return internal.Plans{}, fmt.Errorf("pulse: destination plan %s not found", ref)
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, plan := range matches {
+		ids[i] = plan.ID
+	}
+	return internal.Plans{}, fmt.Errorf("pulse: destination plan name %s is ambiguous: %s", ref, strings.Join(ids, ", "))
+}
+
+func (c *Client) listPlans(ctx context.Context) ([]internal.Plans, error) {
+	plans := make([]internal.Plans, 0)
+	offset := 0
+
+	for {
+		url := fmt.Sprintf("%s/pulseviews/api/apps/%s/plans/paged?limit=50&sort_by=desc&order=ASC&offset=%d&_=%d",
+			c.baseURL, c.appName, offset, time.Now().UnixNano()/int64(time.Millisecond))
+		var page internal.PlansPage
+		if err := c.getJSON(ctx, url, &page); err != nil {
+			return nil, err
+		}
+
+		plans = append(plans, page.Items...)
+		offset += len(page.Items)
+		if page.LastPage || len(page.Items) == 0 || offset >= page.CollectionSize {
+			break
+		}
+	}
+
+	return plans, nil
+}
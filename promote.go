@@ -0,0 +1,161 @@
+package pulse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jcaberio/go-pulse/archive"
+)
+
+// PromoteOptions configures PromoteApp.
+type PromoteOptions struct {
+	// AppName is the name of the application in the destination environment. It defaults to
+	// the application of the destination client, then to the one of the source client.
+	AppName string
+	// ListIDs maps source managed list identifiers to destination identifiers.
+	ListIDs map[string]string
+	// StripListItems removes managed list items from the export.
+	StripListItems bool
+	// GroupIDs maps source ownership group identifiers to destination identifiers.
+	GroupIDs map[string]string
+	// Placeholders replaces ${NAME} occurrences in the export, see archive.Transform.
+	Placeholders map[string]string
+	// Import configures the import in the destination environment.
+	Import *ImportAppOptions
+	// DryRun stops after the destination has prepared the import, which is then cancelled.
+	DryRun bool
+	// WorkDir is the directory where the export archives are written. It defaults to a
+	// temporary directory removed once the promotion ends.
+	WorkDir string
+}
+
+// PromoteReport describes the outcome of PromoteApp.
+type PromoteReport struct {
+	SourceApp      string
+	DestinationApp string
+	ExportFile     string
+	ImportFile     string
+	GroupMatching  map[string]string
+	Plan           *AppImportPlan
+	DryRun         bool
+	Imported       bool
+	Started        bool
+	PublishStatus  string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+}
+
+// PromoteApp exports the application of src, rewrites the export according to opts, imports it
+// through dst, starts it and waits for the publish to finish. A dry run only prepares the import,
+// reports the resulting plan and cancels the import. The returned report describes how far the
+// promotion went, also when an error is returned.
+func PromoteApp(ctx context.Context, src *Client, dst *Client, opts *PromoteOptions) (*PromoteReport, error) {
+	if opts == nil {
+		opts = &PromoteOptions{}
+	}
+	if src.appName == "" {
+		return nil, errors.New("pulse: empty source app name")
+	}
+
+	report := &PromoteReport{
+		SourceApp:      src.appName,
+		DestinationApp: opts.AppName,
+		DryRun:         opts.DryRun,
+		StartedAt:      time.Now(),
+	}
+	if report.DestinationApp == "" {
+		report.DestinationApp = dst.appName
+	}
+	if report.DestinationApp == "" {
+		report.DestinationApp = src.appName
+	}
+	target := dst.App(report.DestinationApp).Client
+
+	args := map[string]string{
+		"sourceBaseURL": src.baseURL,
+		"sourceApp":     report.SourceApp,
+		"dryRun":        strconv.FormatBool(opts.DryRun),
+	}
+	err := target.mutate(ctx, "PromoteApp", args, func() error {
+		return promote(ctx, src, target, opts, report)
+	})
+	report.FinishedAt = time.Now()
+	return report, err
+}
+
+func promote(ctx context.Context, src *Client, dst *Client, opts *PromoteOptions, report *PromoteReport) error {
+	workDir := opts.WorkDir
+	if workDir == "" {
+		dir, err := ioutil.TempDir("", "pulse-promote")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		workDir = dir
+	}
+
+	report.ExportFile = filepath.Join(workDir, src.appName+".zip")
+	if err := src.exportApp(ctx, report.ExportFile, opts.StripListItems); err != nil {
+		return err
+	}
+
+	transform := &archive.Transform{
+		AppFrom:       src.appName,
+		ListIDs:       opts.ListIDs,
+		GroupIDs:      opts.GroupIDs,
+		DropListItems: opts.StripListItems,
+		Placeholders:  opts.Placeholders,
+	}
+	if dst.appName != src.appName {
+		transform.AppName = dst.appName
+	}
+	report.ImportFile = filepath.Join(workDir, dst.appName+"-import.zip")
+	if err := archive.Rewrite(report.ExportFile, report.ImportFile, transform); err != nil {
+		return err
+	}
+
+	plan, err := dst.prepareAppImport(ctx, report.ImportFile)
+	if err != nil {
+		return err
+	}
+	report.Plan = plan
+
+	// The prepared import is cancelled unless it is committed, keeping the error that stopped it.
+	var previousID string
+	report.GroupMatching, err = plan.matchGroups(opts.Import)
+	if err == nil && !opts.DryRun {
+		previousID, err = dst.currentOperationID(ctx)
+	}
+	if err == nil && !opts.DryRun {
+		err = dst.commitImport(ctx, plan.ImportID, report.GroupMatching)
+	}
+	if err != nil || opts.DryRun {
+		if cancelErr := dst.cancelImport(ctx, plan.ImportID); err == nil {
+			err = cancelErr
+		}
+		return err
+	}
+	report.Imported = true
+
+	if err := dst.start(ctx); err != nil {
+		return err
+	}
+	report.Started = true
+
+	progress, err := dst.waitForPublish(ctx, previousID)
+	if err != nil {
+		return err
+	}
+	operation := newOperation(*progress)
+	report.PublishStatus = operation.Status
+	if operation.Failed() {
+		return fmt.Errorf("pulse: publish %s failed (status %s)", operation.ID, operation.Status)
+	}
+	return nil
+}
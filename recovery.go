@@ -0,0 +1,81 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RestartOptions configures RestartWithOptions.
+type RestartOptions struct {
+	// RecoveryExpression replaces the recovery expression of the workflow, telling from which
+	// point events are replayed on restart. It is validated before the workflow is saved.
+	RecoveryExpression string
+	// SkipRecovery restarts the application without replaying events.
+	SkipRecovery bool
+}
+
+// RecoveryExpressionError reports a recovery expression rejected by Pulse.
+type RecoveryExpressionError struct {
+	Expression string
+	// Messages are the validation messages returned by Pulse.
+	Messages []string
+}
+
+func (e *RecoveryExpressionError) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("pulse: invalid recovery expression %q", e.Expression)
+	}
+	return fmt.Sprintf("pulse: invalid recovery expression %q: %s", e.Expression, strings.Join(e.Messages, "; "))
+}
+
+// RestartWithOptions saves the main workflow back, optionally with another recovery expression,
+// and publishes the application, replaying events from the recovery expression unless
+// opts.SkipRecovery is set.
+func (c *Client) RestartWithOptions(ctx context.Context, opts *RestartOptions) error {
+	if opts == nil {
+		opts = &RestartOptions{}
+	}
+	args := map[string]string{"skipRecovery": strconv.FormatBool(opts.SkipRecovery)}
+	if opts.RecoveryExpression != "" {
+		args["recoveryExpression"] = opts.RecoveryExpression
+	}
+	return c.mutate(ctx, "Restart", args, func() error {
+		return c.restart(ctx, opts)
+	})
+}
+
+// ValidateRecoveryExpression checks expr against the application. A rejected expression, answered
+// with status 400 or 422, is reported as a *RecoveryExpressionError carrying the messages of
+// Pulse; any other failure is a plain error.
+func (c *Client) ValidateRecoveryExpression(ctx context.Context, expr string) error {
+	return c.validateRestoreState(ctx, expr)
+}
+
+// recoveryMessages extracts the validation messages of a validaterestorestate response, which is
+// either a single error or a list of errors, bare or under "errors".
+func recoveryMessages(body []byte) []string {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(body, &list); err != nil {
+		var object struct {
+			Errors []json.RawMessage `json:"errors"`
+		}
+		if err := json.Unmarshal(body, &object); err == nil && len(object.Errors) > 0 {
+			list = object.Errors
+		} else {
+			list = []json.RawMessage{body}
+		}
+	}
+
+	messages := make([]string, len(list))
+	for i, raw := range list {
+		messages[i] = newImportError(raw).Message
+	}
+	return messages
+}
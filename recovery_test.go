@@ -0,0 +1,55 @@
+package pulse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateRecoveryExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		invalid bool
+		wantErr string
+	}{
+		{"valid", http.StatusNoContent, "", false, ""},
+		{"bad request", http.StatusBadRequest, `{"errors":[{"message":"unknown field x"}]}`, true, "unknown field x"},
+		{"unprocessable", http.StatusUnprocessableEntity, "", true, "invalid recovery expression"},
+		{"unauthorized", http.StatusUnauthorized, "session expired", false, "status 401: session expired"},
+		{"server error", http.StatusInternalServerError, "boom", false, "status 500: boom"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/validaterestorestate") {
+					w.WriteHeader(test.status)
+					w.Write([]byte(test.body))
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(&Options{BaseURL: server.URL, AppName: "app", DisableAudit: true, DisableSnapshots: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = client.ValidateRecoveryExpression(context.Background(), "x > 1")
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("got error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("got error %v, want %q", err, test.wantErr)
+			}
+			if _, ok := err.(*RecoveryExpressionError); ok != test.invalid {
+				t.Errorf("got %T, want RecoveryExpressionError %v", err, test.invalid)
+			}
+		})
+	}
+}
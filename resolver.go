@@ -0,0 +1,191 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Matcher selects workflows or workflow elements by ID, exact name or regular expression.
+type Matcher struct {
+	id   string
+	name string
+	re   *regexp.Regexp
+}
+
+// ByID matches the workflow or element with the given ID.
+func ByID(id string) Matcher {
+	return Matcher{id: id}
+}
+
+// ByName matches the workflows or elements named name.
+func ByName(name string) Matcher {
+	return Matcher{name: name}
+}
+
+// ByRef matches the workflow or element with the given ID or, if there is none, the ones
+// named ref.
+func ByRef(ref string) Matcher {
+	return Matcher{id: ref, name: ref}
+}
+
+// ByRegexp matches the workflows or elements whose name matches re.
+func ByRegexp(re *regexp.Regexp) Matcher {
+	return Matcher{re: re}
+}
+
+func (m Matcher) String() string {
+	switch {
+	case m.re != nil:
+		return "/" + m.re.String() + "/"
+	case m.id != "" && m.name != "":
+		return m.id
+	case m.id != "":
+		return "id " + m.id
+	}
+	return m.name
+}
+
+// filter returns the indexes of the n items matching m. Matchers by reference fall back to
+// names only when no ID matches.
+func (m Matcher) filter(n int, id func(i int) string, name func(i int) string) []int {
+	var matches []int
+	if m.id != "" {
+		for i := 0; i < n; i++ {
+			if id(i) == m.id {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) > 0 || m.name == "" {
+			return matches
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if (m.re != nil && m.re.MatchString(name(i))) || (m.re == nil && name(i) == m.name) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// ElementRef identifies an element of a workflow.
+type ElementRef struct {
+	WorkflowID   string
+	WorkflowDesc string
+	ElementID    string
+	ElementDesc  string
+}
+
+func (r ElementRef) String() string {
+	return fmt.Sprintf("%s/%s (%s/%s)", r.WorkflowDesc, r.ElementDesc, r.WorkflowID, r.ElementID)
+}
+
+// AmbiguousError is returned when a workflow element query matches several elements.
+type AmbiguousError struct {
+	Workflow   Matcher
+	Element    Matcher
+	Candidates []ElementRef
+}
+
+func (e *AmbiguousError) Error() string {
+	candidates := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		candidates[i] = candidate.String()
+	}
+	return fmt.Sprintf("pulse: workflow element %s/%s is ambiguous: %s",
+		e.Workflow, e.Element, strings.Join(candidates, ", "))
+}
+
+// FindWorkflowElements returns the elements matching element in the workflows matching workflow.
+// Workflows are read once per session; see InvalidateWorkflowCache.
+func (c *Client) FindWorkflowElements(ctx context.Context, workflow, element Matcher) ([]ElementRef, error) {
+	workflows, err := c.cachedWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []ElementRef
+	for _, i := range workflow.filter(len(workflows),
+		func(i int) string { return workflows[i].ID },
+		func(i int) string { return workflows[i].Desc }) {
+		w := workflows[i]
+		elements := w.Config.Elements
+		for _, j := range element.filter(len(elements),
+			func(j int) string { return elements[j].ID },
+			func(j int) string { return elements[j].Desc }) {
+			refs = append(refs, ElementRef{
+				WorkflowID:   w.ID,
+				WorkflowDesc: w.Desc,
+				ElementID:    elements[j].ID,
+				ElementDesc:  elements[j].Desc,
+			})
+		}
+	}
+	return refs, nil
+}
+
+// ResolveWorkflowElement returns the single element matching element in the workflows matching
+// workflow. It returns an *AmbiguousError listing the candidates when several elements match.
+func (c *Client) ResolveWorkflowElement(ctx context.Context, workflow, element Matcher) (ElementRef, error) {
+	refs, err := c.FindWorkflowElements(ctx, workflow, element)
+	if err != nil {
+		return ElementRef{}, err
+	}
+
+	switch len(refs) {
+	case 0:
+		return ElementRef{}, fmt.Errorf("pulse: workflow element %s/%s not found", workflow, element)
+	case 1:
+		return refs[0], nil
+	}
+	return ElementRef{}, &AmbiguousError{Workflow: workflow, Element: element, Candidates: refs}
+}
+
+// InvalidateWorkflowCache discards the workflows read by FindWorkflowElements and
+// ResolveWorkflowElement.
+func (c *Client) InvalidateWorkflowCache() {
+	c.workflows.invalidate(c.appName)
+}
+
+// workflowCache holds the workflows of each application, shared by the clients of a session.
+type workflowCache struct {
+	mu    sync.Mutex
+	byApp map[string][]*Workflow
+}
+
+func (wc *workflowCache) get(app string) ([]*Workflow, bool) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	workflows, ok := wc.byApp[app]
+	return workflows, ok
+}
+
+func (wc *workflowCache) set(app string, workflows []*Workflow) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	if wc.byApp == nil {
+		wc.byApp = make(map[string][]*Workflow)
+	}
+	wc.byApp[app] = workflows
+}
+
+func (wc *workflowCache) invalidate(app string) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	delete(wc.byApp, app)
+}
+
+func (c *Client) cachedWorkflows(ctx context.Context) ([]*Workflow, error) {
+	if workflows, ok := c.workflows.get(c.appName); ok {
+		return workflows, nil
+	}
+	workflows, err := c.ListWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.workflows.set(c.appName, workflows)
+	return workflows, nil
+}
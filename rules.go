@@ -0,0 +1,119 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WorkflowTarget names a workflow element a rules snapshot is deployed to. Workflow and
+// Element are IDs or names, resolved with ResolveWorkflowElement.
+type WorkflowTarget struct {
+	Workflow string
+	Element  string
+}
+
+// ImportRuleOptions configures ImportRuleWithOptions.
+type ImportRuleOptions struct {
+	// Snapshots maps snapshots, by ID or name, to the workflow elements they are deployed to.
+	Snapshots map[string][]WorkflowTarget
+	// Default lists the workflow elements of snapshots missing from Snapshots. Without
+	// default, an unmapped snapshot is an error.
+	Default []WorkflowTarget
+}
+
+// ImportRuleWithOptions imports the rules projects of the partial export zipFile, deploys each
+// snapshot to the workflow elements given by opts and publishes the application.
+func (c *Client) ImportRuleWithOptions(ctx context.Context, zipFile string, opts *ImportRuleOptions) error {
+	if opts == nil {
+		opts = &ImportRuleOptions{}
+	}
+	args := map[string]string{"zipFile": zipFile}
+	for ref, targets := range opts.Snapshots {
+		args["snapshot:"+ref] = formatTargets(targets)
+	}
+	if len(opts.Default) > 0 {
+		args["default"] = formatTargets(opts.Default)
+	}
+
+	return c.mutate(ctx, "ImportRule", args, func() error {
+		return c.importRule(ctx, zipFile, opts)
+	})
+}
+
+func (c *Client) importRule(ctx context.Context, zipFile string, opts *ImportRuleOptions) error {
+	resolved := make(map[WorkflowTarget]WorkflowMapping)
+	resolve := func(targets []WorkflowTarget) ([]WorkflowMapping, error) {
+		mappings := make([]WorkflowMapping, len(targets))
+		for i, target := range targets {
+			mapping, ok := resolved[target]
+			if !ok {
+				ref, err := c.ResolveWorkflowElement(ctx, ByRef(target.Workflow), ByRef(target.Element))
+				if err != nil {
+					return nil, err
+				}
+				mapping = WorkflowMapping{WorkflowID: ref.WorkflowID, ElementID: ref.ElementID}
+				resolved[target] = mapping
+			}
+			mappings[i] = mapping
+		}
+		return mappings, nil
+	}
+
+	p, err := c.NewPartialImport(ctx, zipFile)
+	if err != nil {
+		return err
+	}
+
+	for _, list := range p.Lists {
+		list.Skip = true
+	}
+
+	var unmapped []string
+	for _, project := range p.RulesProjects {
+		for _, snapshot := range project.Snapshots {
+			targets, ok := opts.Snapshots[snapshot.ID]
+			if !ok {
+				targets, ok = opts.Snapshots[snapshot.Desc]
+			}
+			if !ok {
+				targets = opts.Default
+			}
+			if len(targets) == 0 {
+				unmapped = append(unmapped, project.Desc+"/"+snapshot.Desc)
+				continue
+			}
+
+			if snapshot.WorkflowMappings, err = resolve(targets); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(unmapped) > 0 {
+		sort.Strings(unmapped)
+		return fmt.Errorf("pulse: unmapped snapshots: %s", strings.Join(unmapped, ", "))
+	}
+
+	snapshotted := make(map[string]bool)
+	for _, mapping := range resolved {
+		if snapshotted[mapping.WorkflowID] {
+			continue
+		}
+		if _, err := c.snapshotWorkflow(ctx, mapping.WorkflowID, "ImportRule"); err != nil {
+			return err
+		}
+		snapshotted[mapping.WorkflowID] = true
+	}
+
+	return p.commit(ctx)
+}
+
+func formatTargets(targets []WorkflowTarget) string {
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = target.Workflow + "/" + target.Element
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+
+	"github.com/jcaberio/go-pulse/archive"
+)
+
+// Build validates projects, whose IDs must be unique, and returns the partial export importing
+// them. Each project is stored in rulesprojects/<id>.json and its snapshot, if any, in
+// rulesprojects/<id>/snapshots/<snapshot id>.json.
+func Build(projects ...*Project) (*archive.Archive, error) {
+	if len(projects) == 0 {
+		return nil, errNoProject
+	}
+
+	files := make(map[string][]byte)
+	ids := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		p = p.withDefaults()
+		if err := p.validate(); err != nil {
+			return nil, err
+		}
+		if ids[p.ID] {
+			return nil, fmt.Errorf("rules: duplicate project id %s", p.ID)
+		}
+		ids[p.ID] = true
+
+		rules := make([]interface{}, len(p.Rules))
+		for i, r := range p.Rules {
+			rules[i] = r.object()
+		}
+
+		project := copyExtra(p.Extra)
+		project["id"] = p.ID
+		project["desc"] = p.Name
+		if p.Type != "" {
+			project["type"] = p.Type
+		}
+		project["rules"] = rules
+		if err := addJSON(files, path.Join("rulesprojects", p.ID+".json"), project); err != nil {
+			return nil, err
+		}
+
+		if p.Snapshot == nil {
+			continue
+		}
+		snapshot := copyExtra(p.Snapshot.Extra)
+		snapshot["id"] = p.Snapshot.ID
+		snapshot["desc"] = p.Snapshot.Desc
+		snapshot["rulesProjectId"] = p.ID
+		snapshot["rules"] = rules
+		name := path.Join("rulesprojects", p.ID, "snapshots", p.Snapshot.ID+".json")
+		if err := addJSON(files, name, snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	a := &archive.Archive{Files: files}
+	var buf bytes.Buffer
+	if _, err := a.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return archive.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+}
+
+// BuildFile builds projects into the zip file filename.
+func BuildFile(filename string, projects ...*Project) error {
+	a, err := Build(projects...)
+	if err != nil {
+		return err
+	}
+	return a.WriteFile(filename)
+}
+
+func (r *Rule) object() map[string]interface{} {
+	rule := copyExtra(r.Extra)
+	rule["id"] = r.ID
+	rule["desc"] = r.Name
+	if r.Description != "" {
+		rule["description"] = r.Description
+	}
+	if r.Enabled != nil {
+		rule["enabled"] = *r.Enabled
+	}
+	rule["condition"] = r.Condition
+	if r.Score != nil {
+		rule["score"] = *r.Score
+	}
+	if len(r.Tags) > 0 {
+		rule["tags"] = r.Tags
+	}
+	return rule
+}
+
+func copyExtra(extra map[string]interface{}) map[string]interface{} {
+	object := make(map[string]interface{}, len(extra)+8)
+	for key, value := range extra {
+		object[key] = value
+	}
+	return object
+}
+
+func addJSON(files map[string][]byte, name string, object map[string]interface{}) error {
+	data, err := marshalIndent(object)
+	if err != nil {
+		return err
+	}
+	files[name] = data
+	return nil
+}
@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/jcaberio/go-pulse/archive"
+)
+
+// Decompile returns the rules projects of the export a, sorted by ID. A project whose rules are
+// only found in its snapshots takes the rules of its last snapshot, and only the last snapshot
+// of a project is kept.
+func Decompile(a *archive.Archive) ([]*Project, error) {
+	snapshots := make(map[string][]map[string]interface{})
+	for _, e := range a.Kind(archive.KindSnapshot) {
+		id := archive.SnapshotProject(e)
+		snapshots[id] = append(snapshots[id], e.Object)
+	}
+
+	var projects []*Project
+	for _, e := range a.Kind(archive.KindRulesProject) {
+		p := &Project{
+			ID:    e.ID,
+			Name:  e.Desc,
+			Type:  archive.StringProperty(e.Object, "type"),
+			Extra: extra(e.Object, "id", "desc", "type", "rules", "snapshots"),
+		}
+
+		projectSnapshots := append(archive.ObjectsProperty(e.Object, "snapshots"), snapshots[e.ID]...)
+		rules := archive.ObjectsProperty(e.Object, "rules")
+		if n := len(projectSnapshots); n > 0 {
+			last := projectSnapshots[n-1]
+			p.Snapshot = &Snapshot{
+				ID:    archive.StringProperty(last, "id"),
+				Desc:  archive.StringProperty(last, "desc"),
+				Extra: extra(last, "id", "desc", "rules", "rulesProjectId"),
+			}
+			if len(rules) == 0 {
+				rules = archive.ObjectsProperty(last, "rules")
+			}
+		}
+
+		for _, rule := range rules {
+			p.Rules = append(p.Rules, newRule(rule))
+		}
+		projects = append(projects, p)
+	}
+
+	if len(projects) == 0 {
+		return nil, errNoProject
+	}
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].ID < projects[j].ID
+	})
+	return projects, nil
+}
+
+// DecompileFile returns the rules projects of the export filename.
+func DecompileFile(filename string) ([]*Project, error) {
+	a, err := archive.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Decompile(a)
+}
+
+// newRule maps the properties of a rule object to a Rule. Properties of unexpected types are kept
+// in Extra.
+func newRule(object map[string]interface{}) *Rule {
+	r := &Rule{}
+	consumed := []string{"id", "desc"}
+	r.ID = archive.StringProperty(object, "id")
+	r.Name = archive.StringProperty(object, "desc")
+
+	if description, ok := object["description"].(string); ok {
+		r.Description = description
+		consumed = append(consumed, "description")
+	}
+	if enabled, ok := object["enabled"].(bool); ok {
+		r.Enabled = &enabled
+		consumed = append(consumed, "enabled")
+	}
+	if condition, ok := object["condition"].(string); ok {
+		r.Condition = condition
+		consumed = append(consumed, "condition")
+	}
+	if n, ok := object["score"].(json.Number); ok {
+		if score, err := n.Float64(); err == nil {
+			r.Score = &score
+			consumed = append(consumed, "score")
+		}
+	}
+	if tags, ok := stringSlice(object["tags"]); ok {
+		r.Tags = tags
+		consumed = append(consumed, "tags")
+	}
+
+	r.Extra = extra(object, consumed...)
+	return r
+}
+
+func stringSlice(value interface{}) ([]string, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	strings := make([]string, len(items))
+	for i, item := range items {
+		if strings[i], ok = item.(string); !ok {
+			return nil, false
+		}
+	}
+	return strings, true
+}
+
+// extra returns the properties of object other than the given ones, or nil.
+func extra(object map[string]interface{}, consumed ...string) map[string]interface{} {
+	skip := make(map[string]bool, len(consumed))
+	for _, key := range consumed {
+		skip[key] = true
+	}
+
+	var m map[string]interface{}
+	for key, value := range object {
+		if skip[key] {
+			continue
+		}
+		if m == nil {
+			m = make(map[string]interface{})
+		}
+		m[key] = normalize(value)
+	}
+	return m
+}
@@ -0,0 +1,259 @@
+// Package rules defines a text format for Pulse rules projects, so that rules can be reviewed
+// and versioned as code. Projects are written in YAML or JSON, built into partial exports
+// accepted by ImportRule, and decompiled back from exports.
+//
+// A project looks like:
+//
+//	id: fraud
+//	name: Fraud rules
+//	snapshot:
+//	  desc: Release 42
+//	rules:
+//	  - id: high-amount
+//	    name: High amount
+//	    condition: amount > 1000
+//	    score: 50
+//	    tags: [amount]
+//
+// Properties of the export not covered by the format are kept under extra.
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Project is a rules project.
+type Project struct {
+	// ID identifies the project. It defaults to the name, lower cased and hyphenated.
+	ID   string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Snapshot describes the snapshot the rules are frozen into on import.
+	Snapshot *Snapshot `json:"snapshot,omitempty" yaml:"snapshot,omitempty"`
+	Rules    []*Rule   `json:"rules" yaml:"rules"`
+
+	Extra map[string]interface{} `json:"extra,omitempty" yaml:"extra,omitempty"`
+}
+
+// Snapshot is a frozen version of a rules project.
+type Snapshot struct {
+	// ID identifies the snapshot. It defaults to the project ID.
+	ID   string `json:"id,omitempty" yaml:"id,omitempty"`
+	Desc string `json:"desc" yaml:"desc"`
+
+	Extra map[string]interface{} `json:"extra,omitempty" yaml:"extra,omitempty"`
+}
+
+// Rule is a rule of a project.
+type Rule struct {
+	// ID identifies the rule. It defaults to the name, lower cased and hyphenated.
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Enabled, when not set, leaves the rule in the default state of Pulse.
+	Enabled   *bool    `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Condition string   `json:"condition" yaml:"condition"`
+	Score     *float64 `json:"score,omitempty" yaml:"score,omitempty"`
+	Tags      []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	Extra map[string]interface{} `json:"extra,omitempty" yaml:"extra,omitempty"`
+}
+
+// Parse reads a project written in YAML, or in JSON.
+func Parse(data []byte) (*Project, error) {
+	var p Project
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, err
+	}
+	p.normalize()
+	return &p, nil
+}
+
+// ParseFile reads the project file filename. Files with a .json extension are read as JSON,
+// others as YAML.
+func ParseFile(filename string) (*Project, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *Project
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		p = &Project{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(p)
+		p.normalize()
+	} else {
+		p, err = Parse(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rules: %s: %v", filename, err)
+	}
+	return p, nil
+}
+
+// Marshal writes p in YAML.
+func Marshal(p *Project) ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// MarshalJSON writes p in indented JSON.
+func MarshalJSON(p *Project) ([]byte, error) {
+	return marshalIndent(p)
+}
+
+// marshalIndent encodes v in indented JSON, leaving the comparison operators of conditions
+// unescaped.
+func marshalIndent(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Validate checks that the project and its rules are named, that rules have a condition and
+// that identifiers are unique and usable as file names. Missing identifiers are checked as
+// Build derives them from names; p itself is left unchanged.
+func (p *Project) Validate() error {
+	return p.withDefaults().validate()
+}
+
+func (p *Project) validate() error {
+	var problems []string
+	if p.Name == "" {
+		problems = append(problems, "project without name")
+	}
+	if !ValidID(p.ID) {
+		problems = append(problems, fmt.Sprintf("invalid project id %q", p.ID))
+	}
+	if p.Snapshot != nil && !ValidID(p.Snapshot.ID) {
+		problems = append(problems, fmt.Sprintf("invalid snapshot id %q", p.Snapshot.ID))
+	}
+
+	ids := make(map[string]bool)
+	for i, r := range p.Rules {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+			problems = append(problems, fmt.Sprintf("rule %s without name", name))
+		}
+		if r.ID == "" && r.Name != "" {
+			problems = append(problems, fmt.Sprintf("rule %s without id", name))
+		} else if r.ID != "" && ids[r.ID] {
+			problems = append(problems, fmt.Sprintf("duplicate rule id %s", r.ID))
+		}
+		ids[r.ID] = true
+		if strings.TrimSpace(r.Condition) == "" {
+			problems = append(problems, fmt.Sprintf("rule %s without condition", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("rules: project %s: %s", p.Name, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// withDefaults returns a copy of p whose missing identifiers are derived from names.
+func (p *Project) withDefaults() *Project {
+	q := *p
+	if q.ID == "" {
+		q.ID = slug(q.Name)
+	}
+	if p.Snapshot != nil {
+		snapshot := *p.Snapshot
+		if snapshot.ID == "" {
+			snapshot.ID = q.ID
+		}
+		q.Snapshot = &snapshot
+	}
+	q.Rules = make([]*Rule, len(p.Rules))
+	for i, r := range p.Rules {
+		rule := *r
+		if rule.ID == "" {
+			rule.ID = slug(rule.Name)
+		}
+		q.Rules[i] = &rule
+	}
+	return &q
+}
+
+// ValidID tells whether id can name a project or snapshot file: it is not empty, and holds
+// neither path separators nor "..".
+func ValidID(id string) bool {
+	return id != "" && !strings.ContainsAny(id, `/\`) && !strings.Contains(id, "..")
+}
+
+var slugInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(name string) string {
+	return strings.Trim(slugInvalid.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// normalize converts the extra properties read from YAML into values encoding/json accepts.
+func (p *Project) normalize() {
+	p.Extra = normalizeMap(p.Extra)
+	if p.Snapshot != nil {
+		p.Snapshot.Extra = normalizeMap(p.Snapshot.Extra)
+	}
+	for _, r := range p.Rules {
+		r.Extra = normalizeMap(r.Extra)
+	}
+}
+
+func normalizeMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	normalized := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		normalized[key] = normalize(value)
+	}
+	return normalized
+}
+
+// normalize returns a copy of value where the map[interface{}]interface{} values of yaml.v2 are
+// string keyed maps and the json.Number values of exports are numbers.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalize(item)
+		}
+		return m
+	case map[string]interface{}:
+		return normalizeMap(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = normalize(item)
+		}
+		return items
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+	return value
+}
+
+var errNoProject = errors.New("rules: no rules project")
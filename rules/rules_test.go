@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const fraudYAML = `
+name: Fraud rules
+snapshot:
+  desc: Release 42
+rules:
+  - name: High amount
+    condition: amount > 1000
+    score: 50
+    tags: [amount]
+  - id: night
+    name: Night
+    enabled: false
+    condition: hour < 6
+    extra:
+      priority: 2
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"project", fraudYAML, false},
+		{"json", `{"name": "Fraud", "rules": [{"name": "a", "condition": "x > 1"}]}`, false},
+		{"unknown field", "name: Fraud\nrulez: []\n", true},
+		{"invalid yaml", "name: [\n", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.data))
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	rule := func(id, name, condition string) *Rule {
+		return &Rule{ID: id, Name: name, Condition: condition}
+	}
+	tests := []struct {
+		name    string
+		project Project
+		wantErr string
+	}{
+		{"valid", Project{Name: "Fraud", Rules: []*Rule{rule("", "a", "x > 1")}}, ""},
+		{"derived snapshot id", Project{Name: "Fraud", Snapshot: &Snapshot{Desc: "v1"}}, ""},
+		{"no name", Project{ID: "fraud"}, "project without name"},
+		{"unnamed rule", Project{Name: "Fraud", Rules: []*Rule{rule("a", "", "x > 1")}}, "rule #1 without name"},
+		{"no condition", Project{Name: "Fraud", Rules: []*Rule{rule("", "a", " ")}}, "rule a without condition"},
+		{"duplicate derived ids", Project{Name: "Fraud", Rules: []*Rule{rule("", "High amount", "x"), rule("high-amount", "b", "y")}}, "duplicate rule id high-amount"},
+		{"path in project id", Project{ID: "../fraud", Name: "Fraud"}, `invalid project id "../fraud"`},
+		{"separator in snapshot id", Project{Name: "Fraud", Snapshot: &Snapshot{ID: `a\b`}}, `invalid snapshot id "a\\b"`},
+		{"name without letters", Project{Name: "!!!"}, `invalid project id ""`},
+		{"rule name without letters", Project{Name: "Fraud", Rules: []*Rule{rule("", "???", "x > 1")}}, "rule ??? without id"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before, err := MarshalJSON(&test.project)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = test.project.Validate()
+			if test.wantErr == "" && err != nil {
+				t.Errorf("got error %v", err)
+			}
+			if test.wantErr != "" && (err == nil || !strings.Contains(err.Error(), test.wantErr)) {
+				t.Errorf("got error %v, want %q", err, test.wantErr)
+			}
+
+			after, err := MarshalJSON(&test.project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(before) != string(after) {
+				t.Errorf("Validate changed the project:\n%s", after)
+			}
+		})
+	}
+}
+
+func TestBuildDecompile(t *testing.T) {
+	p, err := Parse([]byte(fraudYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := Build(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "" || p.Rules[0].ID != "" {
+		t.Errorf("Build changed the project IDs to %q and %q", p.ID, p.Rules[0].ID)
+	}
+	var names []string
+	for name := range a.Files {
+		names = append(names, name)
+	}
+	if len(names) != 2 || a.Files["rulesprojects/fraud-rules.json"] == nil ||
+		a.Files["rulesprojects/fraud-rules/snapshots/fraud-rules.json"] == nil {
+		t.Errorf("got files %q", names)
+	}
+
+	projects, err := Decompile(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("got %d projects, want 1", len(projects))
+	}
+	got := projects[0]
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"project id", got.ID, "fraud-rules"},
+		{"project name", got.Name, "Fraud rules"},
+		{"snapshot", *got.Snapshot, Snapshot{ID: "fraud-rules", Desc: "Release 42"}},
+		{"rule ids", []string{got.Rules[0].ID, got.Rules[1].ID}, []string{"high-amount", "night"}},
+		{"condition", got.Rules[0].Condition, "amount > 1000"},
+		{"score", *got.Rules[0].Score, 50.0},
+		{"tags", got.Rules[0].Tags, []string{"amount"}},
+		{"enabled", *got.Rules[1].Enabled, false},
+		{"extra", len(got.Rules[1].Extra), 1},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestBuildInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []*Project
+	}{
+		{"no project", nil},
+		{"invalid project", []*Project{{Name: "Fraud", Rules: []*Rule{{Name: "a"}}}}},
+		{"unsafe id", []*Project{{ID: "../../etc", Name: "Fraud"}}},
+		{"duplicate ids", []*Project{{Name: "Fraud"}, {ID: "fraud", Name: "Other"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Build(test.projects...); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
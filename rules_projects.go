@@ -0,0 +1,248 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/jcaberio/go-pulse/internal"
+)
+
+// RulesProject is a rules project of the application.
+type RulesProject struct {
+	ID        string
+	Desc      string
+	Type      string
+	Snapshots []RulesSnapshot
+}
+
+// RulesSnapshot is a frozen version of a rules project, run by the workflow elements it is
+// mapped to.
+type RulesSnapshot struct {
+	ID               string
+	Desc             string
+	WorkflowMappings []WorkflowMapping
+}
+
+// Snapshot returns the snapshot identified by ref, an ID or a name, or nil.
+func (p *RulesProject) Snapshot(ref string) *RulesSnapshot {
+	for i := range p.Snapshots {
+		if p.Snapshots[i].ID == ref {
+			return &p.Snapshots[i]
+		}
+	}
+	for i := range p.Snapshots {
+		if p.Snapshots[i].Desc == ref {
+			return &p.Snapshots[i]
+		}
+	}
+	return nil
+}
+
+// ListRulesProjects returns the rules projects of the application, without their snapshots.
+func (c *Client) ListRulesProjects(ctx context.Context) ([]RulesProject, error) {
+	projects := make([]RulesProject, 0)
+	offset := 0
+
+	for {
+		url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rulesprojects/paged?limit=50&sort_by=desc&order=ASC&offset=%d&_=%d",
+			c.baseURL, c.appName, offset, time.Now().UnixNano()/int64(time.Millisecond))
+		var page internal.RulesProjectsPage
+		if err := c.getJSON(ctx, url, &page); err != nil {
+			return nil, err
+		}
+
+		for _, project := range page.Items {
+			projects = append(projects, RulesProject{ID: project.ID, Desc: project.Desc, Type: project.Type})
+		}
+		offset += len(page.Items)
+		if page.LastPage || len(page.Items) == 0 || offset >= page.CollectionSize {
+			break
+		}
+	}
+
+	return projects, nil
+}
+
+// GetRulesProject returns the rules project identified by ref, an ID or a name, with its
+// snapshots.
+func (c *Client) GetRulesProject(ctx context.Context, ref string) (*RulesProject, error) {
+	projects, err := c.ListRulesProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	project := findRulesProject(projects, ref)
+	if project == nil {
+		return nil, fmt.Errorf("pulse: rules project %s not found", ref)
+	}
+
+	if project.Snapshots, err = c.listSnapshots(ctx, project.ID); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func findRulesProject(projects []RulesProject, ref string) *RulesProject {
+	for i := range projects {
+		if projects[i].ID == ref {
+			return &projects[i]
+		}
+	}
+	for i := range projects {
+		if projects[i].Desc == ref {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+// ListSnapshots returns the snapshots of the rules project identified by project, an ID or a name.
+func (c *Client) ListSnapshots(ctx context.Context, project string) ([]RulesSnapshot, error) {
+	p, err := c.GetRulesProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	return p.Snapshots, nil
+}
+
+func (c *Client) listSnapshots(ctx context.Context, projectID string) ([]RulesSnapshot, error) {
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rulesprojects/%s/snapshots?_=%d",
+		c.baseURL, c.appName, projectID, time.Now().UnixNano()/int64(time.Millisecond))
+	var snapshots []internal.Snapshot
+	if err := c.getJSON(ctx, url, &snapshots); err != nil {
+		return nil, err
+	}
+
+	result := make([]RulesSnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		result[i] = newRulesSnapshot(snapshot)
+	}
+	return result, nil
+}
+
+func newRulesSnapshot(snapshot internal.Snapshot) RulesSnapshot {
+	s := RulesSnapshot{ID: snapshot.ID, Desc: snapshot.Desc}
+	for _, mapping := range snapshot.WorkflowMappings {
+		s.WorkflowMappings = append(s.WorkflowMappings, WorkflowMapping{
+			WorkflowID: mapping.WorkflowId,
+			ElementID:  mapping.WorkflowElementId,
+		})
+	}
+	return s
+}
+
+// CreateSnapshot freezes the current rules of the project identified by project, an ID or a name,
+// into a new snapshot described by desc.
+func (c *Client) CreateSnapshot(ctx context.Context, project, desc string) (*RulesSnapshot, error) {
+	var snapshot *RulesSnapshot
+	args := map[string]string{"project": project, "desc": desc}
+	err := c.mutate(ctx, "CreateSnapshot", args, func() error {
+		var err error
+		snapshot, err = c.createSnapshot(ctx, project, desc)
+		return err
+	})
+	return snapshot, err
+}
+
+func (c *Client) createSnapshot(ctx context.Context, project, desc string) (*RulesSnapshot, error) {
+	p, err := c.GetRulesProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(internal.CreateSnapshotRequest{Desc: desc})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rulesprojects/%s/snapshots", c.baseURL, c.appName, p.ID)
+	resp, err := c.post(ctx, url, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("pulse: %s\n", body)
+	}
+
+	var created internal.Snapshot
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, err
+	}
+	snapshot := newRulesSnapshot(created)
+	return &snapshot, nil
+}
+
+// ExportRulesProject downloads the rules project identified by project, an ID or a name, to the
+// zip file filename, in the format accepted by ImportRule.
+func (c *Client) ExportRulesProject(ctx context.Context, project, filename string) error {
+	p, err := c.GetRulesProject(ctx, project)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rulesprojects/%s/export", c.baseURL, c.appName, p.ID)
+	return c.download(ctx, filename, url)
+}
+
+// ActivateSnapshot deploys the snapshot identified by snapshot, an ID or a name, of the rules
+// project identified by project to workflowElement, then publishes the application.
+func (c *Client) ActivateSnapshot(ctx context.Context, project, snapshot string, workflowElement WorkflowTarget) error {
+	args := map[string]string{
+		"project":  project,
+		"snapshot": snapshot,
+		"target":   formatTargets([]WorkflowTarget{workflowElement}),
+	}
+	return c.mutate(ctx, "ActivateSnapshot", args, func() error {
+		return c.activateSnapshot(ctx, project, snapshot, workflowElement)
+	})
+}
+
+func (c *Client) activateSnapshot(ctx context.Context, project, snapshot string, workflowElement WorkflowTarget) error {
+	p, err := c.GetRulesProject(ctx, project)
+	if err != nil {
+		return err
+	}
+	s := p.Snapshot(snapshot)
+	if s == nil {
+		return fmt.Errorf("pulse: snapshot %s of rules project %s not found", snapshot, p.Desc)
+	}
+
+	ref, err := c.ResolveWorkflowElement(ctx, ByRef(workflowElement.Workflow), ByRef(workflowElement.Element))
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.snapshotWorkflow(ctx, ref.WorkflowID, "ActivateSnapshot"); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(internal.Snapshot{
+		ID:   s.ID,
+		Desc: s.Desc,
+		WorkflowMappings: []internal.WorkflowMapping{{
+			WorkflowId:        ref.WorkflowID,
+			WorkflowElementId: ref.ElementID,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rulesprojects/%s/snapshots/%s/activate",
+		c.baseURL, c.appName, p.ID, s.ID)
+	if err := c.submit(ctx, url, http.MethodPost, payload, http.StatusOK, "pulse: failed to activate snapshot"); err != nil {
+		return err
+	}
+	c.InvalidateWorkflowCache()
+
+	return c.update(ctx)
+}
@@ -0,0 +1,41 @@
+package pulse
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportRuleWithOptionsNil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/partialImportPrepare") {
+			w.Write([]byte(`{"importId":"i1","rulesProjects":[{"id":"p1","desc":"Fraud","snapshots":[{"id":"s1","desc":"v1"}]}]}`))
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pulse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	zipFile := filepath.Join(dir, "rules.zip")
+	if err := ioutil.WriteFile(zipFile, []byte("zip"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := New(&Options{BaseURL: server.URL, AppName: "app", DisableAudit: true, DisableSnapshots: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.ImportRuleWithOptions(context.Background(), zipFile, nil)
+	if err == nil || !strings.Contains(err.Error(), "unmapped snapshots: Fraud/v1") {
+		t.Errorf("ImportRuleWithOptions(nil) = %v, want unmapped snapshots error", err)
+	}
+}
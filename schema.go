@@ -0,0 +1,41 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Schema is a data schema of the application, such as the input schema of a workflow.
+type Schema struct {
+	ID     string        `json:"id"`
+	Desc   string        `json:"desc"`
+	Fields []SchemaField `json:"fields"`
+}
+
+// SchemaField is a field of a schema.
+type SchemaField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Field returns the field with the given name, or nil.
+func (s *Schema) Field(name string) *SchemaField {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+// GetSchema returns the schema with the given ID.
+func (c *Client) GetSchema(ctx context.Context, id string) (*Schema, error) {
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/schemas/%s?_=%d",
+		c.baseURL, c.appName, id, time.Now().UnixNano()/int64(time.Millisecond))
+	var schema Schema
+	if err := c.getJSON(ctx, url, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
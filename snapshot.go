@@ -0,0 +1,204 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrSnapshotNotFound is returned by a SnapshotStore when the requested snapshot does not exist.
+var ErrSnapshotNotFound = errors.New("pulse: workflow snapshot not found")
+
+// WorkflowSnapshot is the state of a workflow saved before the client modified it.
+type WorkflowSnapshot struct {
+	ID         string    `json:"id"`
+	App        string    `json:"app"`
+	WorkflowID string    `json:"workflowId"`
+	Operation  string    `json:"operation"`
+	Actor      string    `json:"actor,omitempty"`
+	Time       time.Time `json:"time"`
+	// Workflow is the workflow as returned by Pulse.
+	Workflow json.RawMessage `json:"workflow"`
+}
+
+// SnapshotStore stores workflow snapshots.
+type SnapshotStore interface {
+	// Save stores snapshot under snapshot.ID.
+	Save(snapshot *WorkflowSnapshot) error
+	// Load returns the snapshot with the given ID, or ErrSnapshotNotFound.
+	Load(id string) (*WorkflowSnapshot, error)
+	// List returns the snapshots of app, oldest first.
+	List(app string) ([]*WorkflowSnapshot, error)
+}
+
+// DirSnapshotStore stores workflow snapshots in a directory, one JSON file per snapshot.
+type DirSnapshotStore struct {
+	dir string
+}
+
+// NewDirSnapshotStore returns a store keeping its snapshots in dir, creating it if needed.
+func NewDirSnapshotStore(dir string) (*DirSnapshotStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DirSnapshotStore{dir: dir}, nil
+}
+
+// Save writes snapshot to <dir>/<id>.json.
+func (s *DirSnapshotStore) Save(snapshot *WorkflowSnapshot) error {
+	filename, err := s.filename(snapshot.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, data, 0600)
+}
+
+// Load reads the snapshot with the given ID.
+func (s *DirSnapshotStore) Load(id string) (*WorkflowSnapshot, error) {
+	filename, err := s.filename(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshot WorkflowSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("pulse: snapshot %s: %v", id, err)
+	}
+	return &snapshot, nil
+}
+
+// List reads the snapshots of app found in the directory.
+func (s *DirSnapshotStore) List(app string) ([]*WorkflowSnapshot, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*WorkflowSnapshot
+	for _, info := range infos {
+		if info.IsDir() || filepath.Ext(info.Name()) != ".json" {
+			continue
+		}
+		snapshot, err := s.Load(strings.TrimSuffix(info.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.App == app {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+func (s *DirSnapshotStore) filename(id string) (string, error) {
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("pulse: invalid snapshot id %q", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// WorkflowSnapshots returns the snapshots taken of the application workflows, oldest first.
+func (c *Client) WorkflowSnapshots() ([]*WorkflowSnapshot, error) {
+	if c.snapshots == nil {
+		return nil, errors.New("pulse: snapshots are disabled")
+	}
+	return c.snapshots.List(c.appName)
+}
+
+// RollbackWorkflow restores the workflow saved in the snapshot snapshotID: the saved state is
+// validated, saved back and the application is published. The state being replaced is itself
+// snapshotted, so a rollback can be undone.
+func (c *Client) RollbackWorkflow(ctx context.Context, snapshotID string) error {
+	return c.mutate(ctx, "RollbackWorkflow", map[string]string{"snapshot": snapshotID}, func() error {
+		return c.rollbackWorkflow(ctx, snapshotID)
+	})
+}
+
+func (c *Client) rollbackWorkflow(ctx context.Context, snapshotID string) error {
+	if c.snapshots == nil {
+		return errors.New("pulse: snapshots are disabled")
+	}
+	snapshot, err := c.snapshots.Load(snapshotID)
+	if err != nil {
+		return err
+	}
+	if snapshot.App != c.appName {
+		return fmt.Errorf("pulse: snapshot %s belongs to application %s", snapshotID, snapshot.App)
+	}
+
+	if err := c.validate(ctx, snapshot.Workflow); err != nil {
+		return err
+	}
+
+	if _, err := c.snapshotWorkflow(ctx, snapshot.WorkflowID, "RollbackWorkflow"); err != nil {
+		return err
+	}
+
+	if err := c.saveWorkflow(ctx, snapshot.WorkflowID, snapshot.Workflow); err != nil {
+		return err
+	}
+
+	return c.update(ctx)
+}
+
+// snapshotWorkflow saves the current state of the workflow workflowID before operation
+// modifies it. It does nothing when snapshots are disabled.
+func (c *Client) snapshotWorkflow(ctx context.Context, workflowID, operation string) (*WorkflowSnapshot, error) {
+	if c.snapshots == nil {
+		return nil, nil
+	}
+	body, _, err := c.getWorkflowState(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	return c.saveSnapshot(workflowID, operation, body)
+}
+
+func (c *Client) saveSnapshot(workflowID, operation string, body []byte) (*WorkflowSnapshot, error) {
+	if c.snapshots == nil {
+		return nil, nil
+	}
+	now := time.Now().UTC()
+	snapshot := &WorkflowSnapshot{
+		ID: fmt.Sprintf("%s-%s-%s", now.Format("20060102T150405.000000000Z"),
+			snapshotName(c.appName), snapshotName(workflowID)),
+		App:        c.appName,
+		WorkflowID: workflowID,
+		Operation:  operation,
+		Actor:      c.username,
+		Time:       now,
+		Workflow:   json.RawMessage(body),
+	}
+	if err := c.snapshots.Save(snapshot); err != nil {
+		return nil, fmt.Errorf("pulse: snapshot: %v", err)
+	}
+	return snapshot, nil
+}
+
+var snapshotNameInvalid = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+func snapshotName(s string) string {
+	return snapshotNameInvalid.ReplaceAllString(s, "_")
+}
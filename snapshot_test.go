@@ -0,0 +1,60 @@
+package pulse
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewSnapshotStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pulse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The default store lives in the user cache directory.
+	for _, env := range []string{"HOME", "XDG_CACHE_HOME"} {
+		defer os.Setenv(env, os.Getenv(env))
+		os.Setenv(env, dir)
+	}
+
+	tests := []struct {
+		name    string
+		options Options
+		dir     string
+		enabled bool
+	}{
+		{"default", Options{}, "", true},
+		{"directory", Options{SnapshotDir: filepath.Join(dir, "custom")}, filepath.Join(dir, "custom"), true},
+		{"disabled", Options{DisableSnapshots: true}, "", false},
+		{"disabled directory", Options{SnapshotDir: filepath.Join(dir, "unused"), DisableSnapshots: true}, filepath.Join(dir, "unused"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.options.BaseURL = server.URL
+			test.options.AppName = "app"
+			test.options.DisableAudit = true
+			client, err := New(&test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			if _, err := client.WorkflowSnapshots(); (err == nil) != test.enabled {
+				t.Errorf("WorkflowSnapshots() = %v, want enabled %v", err, test.enabled)
+			}
+			if test.dir != "" {
+				if _, err := os.Stat(test.dir); (err == nil) != test.enabled {
+					t.Errorf("snapshot directory exists: %v, want %v", err == nil, test.enabled)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Workflow is a Pulse RTE workflow. Properties not modeled by the client are kept in the Extra
+// fields of the workflow and of its parts, and optional properties Pulse leaves out are left
+// out again, so that a workflow read from Pulse is saved back without losing information.
+type Workflow struct {
+	ID                string         `json:"id"`
+	App               string         `json:"app,omitempty"`
+	Name              string         `json:"name,omitempty"`
+	Desc              string         `json:"desc"`
+	BaseInputSchemaID string         `json:"baseInputSchemaId,omitempty"`
+	Config            WorkflowConfig `json:"config"`
+	OutcomeConfig     OutcomeConfig  `json:"outcomeConfig"`
+	CreatedAt         int64          `json:"createdAt,omitempty"`
+	CreatedBy         string         `json:"createdBy,omitempty"`
+	UpdatedAt         int64          `json:"updatedAt,omitempty"`
+	UpdatedBy         string         `json:"updatedBy,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// WorkflowConfig is the graph of a workflow and its runtime settings.
+type WorkflowConfig struct {
+	ActionHandlers      []json.RawMessage    `json:"actionHandlers,omitempty"`
+	Actions             []WorkflowAction     `json:"actions,omitempty"`
+	AdvancedProperties  AdvancedProperties   `json:"advancedProperties"`
+	Connections         []WorkflowConnection `json:"connections"`
+	Elements            []WorkflowElement    `json:"elements"`
+	EventStorageEnabled bool                 `json:"eventStorageEnabled"`
+	PartitionKeys       []string             `json:"partitionKeys,omitempty"`
+	RecoveryExpression  string               `json:"recoveryExpression,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// WorkflowAction is an action triggered by a workflow.
+type WorkflowAction struct {
+	Name   string            `json:"name"`
+	Params []json.RawMessage `json:"params,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// AdvancedProperties are the advanced settings of a workflow.
+type AdvancedProperties struct {
+	NumberEventStorageSplits string `json:"numberEventStorageSplits,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// WorkflowConnection links the output of the element SourceID to the input of the element SinkID.
+type WorkflowConnection struct {
+	ID       string            `json:"id"`
+	SourceID string            `json:"sourceId"`
+	SinkID   string            `json:"sinkId"`
+	Filter   *ConnectionFilter `json:"filter,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// ConnectionFilter restricts the events flowing through a connection.
+type ConnectionFilter struct {
+	Fields   []string `json:"fields,omitempty"`
+	Template string   `json:"template,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// WorkflowElement is a node of a workflow, such as a rules project, a model or a plan.
+type WorkflowElement struct {
+	Type          string          `json:"@type"`
+	ID            string          `json:"id"`
+	Desc          string          `json:"desc"`
+	DependencyID  string          `json:"dependencyId,omitempty"`
+	Metadata      string          `json:"metadata,omitempty"`
+	OutcomeIDs    []string        `json:"outcomeIds,omitempty"`
+	Configuration json.RawMessage `json:"configuration,omitempty"`
+	InputMapping  json.RawMessage `json:"inputMapping,omitempty"`
+	OutputMapping json.RawMessage `json:"outputMapping,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// OutcomeConfig lists the outcomes produced by a workflow.
+type OutcomeConfig struct {
+	Outcomes []Outcome `json:"outcomes"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Outcome is a value produced by a workflow, such as a score or a decision.
+type Outcome struct {
+	Type              string      `json:"@type"`
+	ID                string      `json:"id"`
+	Label             string      `json:"label"`
+	DefaultValue      interface{} `json:"defaultValue,omitempty"`
+	CategoricalValues []string    `json:"categoricalValues,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (w *Workflow) UnmarshalJSON(data []byte) (err error) {
+	type alias Workflow
+	w.Extra, err = unmarshalExtra(data, (*alias)(w))
+	return err
+}
+
+func (w Workflow) MarshalJSON() ([]byte, error) {
+	type alias Workflow
+	return marshalExtra(alias(w), w.Extra)
+}
+
+func (c *WorkflowConfig) UnmarshalJSON(data []byte) (err error) {
+	type alias WorkflowConfig
+	c.Extra, err = unmarshalExtra(data, (*alias)(c))
+	return err
+}
+
+func (c WorkflowConfig) MarshalJSON() ([]byte, error) {
+	type alias WorkflowConfig
+	return marshalExtra(alias(c), c.Extra)
+}
+
+func (a *WorkflowAction) UnmarshalJSON(data []byte) (err error) {
+	type alias WorkflowAction
+	a.Extra, err = unmarshalExtra(data, (*alias)(a))
+	return err
+}
+
+func (a WorkflowAction) MarshalJSON() ([]byte, error) {
+	type alias WorkflowAction
+	return marshalExtra(alias(a), a.Extra)
+}
+
+func (p *AdvancedProperties) UnmarshalJSON(data []byte) (err error) {
+	type alias AdvancedProperties
+	p.Extra, err = unmarshalExtra(data, (*alias)(p))
+	return err
+}
+
+func (p AdvancedProperties) MarshalJSON() ([]byte, error) {
+	type alias AdvancedProperties
+	return marshalExtra(alias(p), p.Extra)
+}
+
+func (c *WorkflowConnection) UnmarshalJSON(data []byte) (err error) {
+	type alias WorkflowConnection
+	c.Extra, err = unmarshalExtra(data, (*alias)(c))
+	return err
+}
+
+func (c WorkflowConnection) MarshalJSON() ([]byte, error) {
+	type alias WorkflowConnection
+	return marshalExtra(alias(c), c.Extra)
+}
+
+func (f *ConnectionFilter) UnmarshalJSON(data []byte) (err error) {
+	type alias ConnectionFilter
+	f.Extra, err = unmarshalExtra(data, (*alias)(f))
+	return err
+}
+
+func (f ConnectionFilter) MarshalJSON() ([]byte, error) {
+	type alias ConnectionFilter
+	return marshalExtra(alias(f), f.Extra)
+}
+
+func (e *WorkflowElement) UnmarshalJSON(data []byte) (err error) {
+	type alias WorkflowElement
+	e.Extra, err = unmarshalExtra(data, (*alias)(e))
+	return err
+}
+
+func (e WorkflowElement) MarshalJSON() ([]byte, error) {
+	type alias WorkflowElement
+	return marshalExtra(alias(e), e.Extra)
+}
+
+func (c *OutcomeConfig) UnmarshalJSON(data []byte) (err error) {
+	type alias OutcomeConfig
+	c.Extra, err = unmarshalExtra(data, (*alias)(c))
+	return err
+}
+
+func (c OutcomeConfig) MarshalJSON() ([]byte, error) {
+	type alias OutcomeConfig
+	return marshalExtra(alias(c), c.Extra)
+}
+
+func (o *Outcome) UnmarshalJSON(data []byte) (err error) {
+	type alias Outcome
+	o.Extra, err = unmarshalExtra(data, (*alias)(o))
+	return err
+}
+
+func (o Outcome) MarshalJSON() ([]byte, error) {
+	type alias Outcome
+	return marshalExtra(alias(o), o.Extra)
+}
+
+// Element returns the element identified by ref, an ID or a name, or nil.
+func (w *Workflow) Element(ref string) *WorkflowElement {
+	for i := range w.Config.Elements {
+		if w.Config.Elements[i].ID == ref {
+			return &w.Config.Elements[i]
+		}
+	}
+	for i := range w.Config.Elements {
+		if w.Config.Elements[i].Desc == ref {
+			return &w.Config.Elements[i]
+		}
+	}
+	return nil
+}
+
+// Connection returns the connection with the given ID, or nil.
+func (w *Workflow) Connection(id string) *WorkflowConnection {
+	for i := range w.Config.Connections {
+		if w.Config.Connections[i].ID == id {
+			return &w.Config.Connections[i]
+		}
+	}
+	return nil
+}
+
+type workflowPage struct {
+	CollectionSize int         `json:"collectionSize"`
+	Items          []*Workflow `json:"items"`
+	LastPage       bool        `json:"lastPage"`
+	Offset         int         `json:"offset"`
+}
+
+// ListWorkflows returns the RTE workflows of the application.
+func (c *Client) ListWorkflows(ctx context.Context) ([]*Workflow, error) {
+	workflows := make([]*Workflow, 0)
+	offset := 0
+
+	for {
+		url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/paged?limit=50&sort_by=desc&order=ASC&offset=%d&_=%d",
+			c.baseURL, c.appName, offset, time.Now().UnixNano()/int64(time.Millisecond))
+		var page workflowPage
+		if err := c.getJSON(ctx, url, &page); err != nil {
+			return nil, err
+		}
+
+		workflows = append(workflows, page.Items...)
+		offset += len(page.Items)
+		if page.LastPage || len(page.Items) == 0 || offset >= page.CollectionSize {
+			break
+		}
+	}
+
+	return workflows, nil
+}
+
+// GetWorkflow returns the workflow identified by name, an ID or a name.
+func (c *Client) GetWorkflow(ctx context.Context, name string) (*Workflow, error) {
+	workflows, err := c.ListWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range workflows {
+		if w.ID == name {
+			return w, nil
+		}
+	}
+	for _, w := range workflows {
+		if w.Desc == name || w.Name == name {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("pulse: workflow %s not found", name)
+}
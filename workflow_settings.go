@@ -0,0 +1,81 @@
+package pulse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SetPartitionKeys sets the input fields events are partitioned by. Use
+// Client.CheckPartitionKeys to check them against the input schema of the workflow.
+func (w *Workflow) SetPartitionKeys(keys ...string) error {
+	if len(keys) == 0 {
+		return errors.New("pulse: no partition key")
+	}
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if key == "" {
+			return errors.New("pulse: empty partition key")
+		}
+		if seen[key] {
+			return fmt.Errorf("pulse: duplicate partition key %s", key)
+		}
+		seen[key] = true
+	}
+	w.Config.PartitionKeys = append([]string(nil), keys...)
+	return nil
+}
+
+// SetEventStorage enables or disables the storage of events. When enabled, events are stored in
+// splits parts, at least one.
+func (w *Workflow) SetEventStorage(enabled bool, splits int) error {
+	if enabled && splits < 1 {
+		return fmt.Errorf("pulse: invalid number of event storage splits %d", splits)
+	}
+	w.Config.EventStorageEnabled = enabled
+	if enabled {
+		w.Config.AdvancedProperties.NumberEventStorageSplits = strconv.Itoa(splits)
+	}
+	return nil
+}
+
+// EventStorageSplits returns the number of parts events are stored in, or 0 when unset.
+func (w *Workflow) EventStorageSplits() (int, error) {
+	splits := w.Config.AdvancedProperties.NumberEventStorageSplits
+	if splits == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(splits)
+	if err != nil {
+		return 0, fmt.Errorf("pulse: invalid number of event storage splits %q", splits)
+	}
+	return n, nil
+}
+
+// CheckPartitionKeys checks that the partition keys of w are fields of its base input schema.
+func (c *Client) CheckPartitionKeys(ctx context.Context, w *Workflow) error {
+	if len(w.Config.PartitionKeys) == 0 {
+		return nil
+	}
+	if w.BaseInputSchemaID == "" {
+		return fmt.Errorf("pulse: workflow %s has no base input schema", w.Desc)
+	}
+
+	schema, err := c.GetSchema(ctx, w.BaseInputSchemaID)
+	if err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, key := range w.Config.PartitionKeys {
+		if schema.Field(key) == nil {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("pulse: partition keys not in schema %s: %s", schema.Desc, strings.Join(unknown, ", "))
+	}
+	return nil
+}
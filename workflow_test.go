@@ -0,0 +1,68 @@
+package pulse
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "minimal",
+			data: `{"id": "wf1", "desc": "Main",
+				"config": {"advancedProperties": {}, "connections": [], "elements": [], "eventStorageEnabled": false},
+				"outcomeConfig": {"outcomes": []}}`,
+		},
+		{
+			name: "complete",
+			data: `{"id": "wf1", "app": "fraud", "name": "main", "desc": "Main", "baseInputSchemaId": "input",
+				"createdAt": 1, "createdBy": "ana", "updatedAt": 2, "updatedBy": "bo",
+				"config": {
+					"actionHandlers": [{"type": "kafka"}],
+					"actions": [{"name": "alert", "params": [1, "x"]}],
+					"advancedProperties": {"numberEventStorageSplits": "4", "retention": 7},
+					"connections": [{"id": "c1", "sourceId": "e1", "sinkId": "e2", "filter": {"template": "a > 1", "mode": "all"}}],
+					"elements": [
+						{"@type": "rules", "id": "e1", "desc": "Rules", "dependencyId": "p1", "metadata": "{}",
+							"outcomeIds": ["score"], "configuration": {"threshold": 1}, "position": {"x": 1}},
+						{"@type": "output", "id": "e2", "desc": "Out"}],
+					"eventStorageEnabled": true,
+					"partitionKeys": ["card"],
+					"recoveryExpression": "true",
+					"version": 3},
+				"outcomeConfig": {"outcomes": [
+					{"@type": "numeric", "id": "score", "label": "Score", "defaultValue": 0},
+					{"@type": "categorical", "id": "decision", "label": "Decision", "defaultValue": "ok",
+						"categoricalValues": ["ok", "ko"], "color": "red"}],
+					"mode": "first"},
+				"tags": ["prod"]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var w Workflow
+			if err := json.Unmarshal([]byte(test.data), &w); err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(&w)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want, got interface{}
+			if err := json.Unmarshal([]byte(test.data), &want); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %s", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package pulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultWorkflowID identifies the main RTE workflow of an application.
+const defaultWorkflowID = "workflow"
+
+// ErrWorkflowConflict is returned by UpdateWorkflow when the workflow was saved by someone
+// else while it was being updated.
+var ErrWorkflowConflict = errors.New("pulse: workflow modified concurrently")
+
+// UpdateWorkflowOptions configures UpdateWorkflow.
+type UpdateWorkflowOptions struct {
+	// Publish publishes the application once the workflow is saved.
+	Publish bool
+}
+
+// UpdateWorkflow fetches the workflow identified by name, an ID or a name, lets mutate change it,
+// validates the result and its recovery expression, then saves it. Changed outcomes and element
+// outcome references are checked with ValidateOutcomes, and changed partition keys against the
+// base input schema. The update fails with ErrWorkflowConflict if the workflow was saved in
+// between, as told by its updatedAt property.
+func (c *Client) UpdateWorkflow(ctx context.Context, name string, mutate func(*Workflow) error, opts *UpdateWorkflowOptions) error {
+	if opts == nil {
+		opts = &UpdateWorkflowOptions{}
+	}
+	args := map[string]string{"workflow": name, "publish": strconv.FormatBool(opts.Publish)}
+	return c.mutate(ctx, "UpdateWorkflow", args, func() error {
+		return c.updateWorkflow(ctx, name, mutate, opts)
+	})
+}
+
+func (c *Client) updateWorkflow(ctx context.Context, name string, mutate func(*Workflow) error, opts *UpdateWorkflowOptions) error {
+	w, err := c.GetWorkflow(ctx, name)
+	if err != nil {
+		return err
+	}
+	updatedAt := w.UpdatedAt
+	partitionKeys := strings.Join(w.Config.PartitionKeys, ",")
+	baseInputSchemaID := w.BaseInputSchemaID
+	outcomes := outcomeState(w)
+
+	if err := mutate(w); err != nil {
+		return err
+	}
+	if outcomeState(w) != outcomes {
+		if err := w.ValidateOutcomes(); err != nil {
+			return err
+		}
+	}
+	if strings.Join(w.Config.PartitionKeys, ",") != partitionKeys || w.BaseInputSchemaID != baseInputSchemaID {
+		if err := c.CheckPartitionKeys(ctx, w); err != nil {
+			return err
+		}
+	}
+
+	body, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+
+	if err := c.validate(ctx, body); err != nil {
+		return err
+	}
+	if err := c.validateRestoreState(ctx, w.Config.RecoveryExpression); err != nil {
+		return err
+	}
+
+	current, err := c.getWorkflow(ctx, w.ID)
+	if err != nil {
+		return err
+	}
+	if current.UpdatedAt != updatedAt {
+		return ErrWorkflowConflict
+	}
+
+	if _, err := c.snapshotWorkflow(ctx, w.ID, "UpdateWorkflow"); err != nil {
+		return err
+	}
+
+	if err := c.saveWorkflow(ctx, w.ID, body); err != nil {
+		return err
+	}
+
+	if !opts.Publish {
+		return nil
+	}
+	return c.update(ctx)
+}
+
+// getWorkflow returns the workflow with the given ID.
+func (c *Client) getWorkflow(ctx context.Context, id string) (*Workflow, error) {
+	url := fmt.Sprintf("%s/pulseviews/api/apps/%s/rte_workflows/%s?_=%d",
+		c.baseURL, c.appName, id, time.Now().UnixNano()/int64(time.Millisecond))
+	var w Workflow
+	if err := c.getJSON(ctx, url, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
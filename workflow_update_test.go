@@ -0,0 +1,87 @@
+package pulse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpdateWorkflowOutcomes(t *testing.T) {
+	// The stored workflow already references an unknown outcome.
+	const workflow = `{"id": "wf1", "desc": "Main", "updatedAt": 1,
+		"config": {"elements": [{"id": "e1", "desc": "Rules", "outcomeIds": ["score", "stale"]}]},
+		"outcomeConfig": {"outcomes": [{"id": "score", "label": "Score"}]}}`
+
+	tests := []struct {
+		name    string
+		mutate  func(*Workflow) error
+		wantErr string
+	}{
+		{
+			name:   "unrelated change",
+			mutate: func(w *Workflow) error { w.Desc = "Main workflow"; return nil },
+		},
+		{
+			name: "removed reference",
+			mutate: func(w *Workflow) error {
+				w.Config.Elements[0].OutcomeIDs = []string{"score"}
+				return nil
+			},
+		},
+		{
+			name: "new reference",
+			mutate: func(w *Workflow) error {
+				w.Config.Elements[0].OutcomeIDs = append(w.Config.Elements[0].OutcomeIDs, "decision")
+				return nil
+			},
+			wantErr: "references unknown outcome decision",
+		},
+		{
+			name: "changed outcomes",
+			mutate: func(w *Workflow) error {
+				w.OutcomeConfig.Outcomes[0].Label = "Risk score"
+				return nil
+			},
+			wantErr: "references unknown outcome stale",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			saved := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/rte_workflows/paged"):
+					w.Write([]byte(`{"items": [` + workflow + `], "lastPage": true}`))
+				case strings.HasSuffix(r.URL.Path, "/validaterestorestate"):
+					w.WriteHeader(http.StatusNoContent)
+				case strings.HasSuffix(r.URL.Path, "/rte_workflows/wf1") && r.Method == http.MethodPut:
+					saved = true
+				case strings.HasSuffix(r.URL.Path, "/rte_workflows/wf1"):
+					w.Write([]byte(workflow))
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(&Options{BaseURL: server.URL, AppName: "app", DisableAudit: true, DisableSnapshots: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = client.UpdateWorkflow(context.Background(), "Main", test.mutate, nil)
+			if test.wantErr == "" {
+				if err != nil || !saved {
+					t.Errorf("got error %v and saved %v, want the workflow saved", err, saved)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want %q", err, test.wantErr)
+			}
+			if saved {
+				t.Error("the workflow was saved")
+			}
+		})
+	}
+}